@@ -0,0 +1,91 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package card provides helpers for writing Drone adaptive cards.
+//
+// Drone renders a card for a step in the UI from a JSON document written to
+// the file given by DRONE_CARD_PATH. The document combines an adaptive card
+// template, the schema, with the data rendered by the template.
+package card
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// PathEnv is the environment variable holding the card file path.
+const PathEnv = "DRONE_CARD_PATH"
+
+// stdout is the card path used to write the card to the step log.
+const stdout = "/dev/stdout"
+
+// Card is the document Drone reads from the card file.
+type Card struct {
+	// Schema is the adaptive card template used to render the card. This is
+	// either a URL to the template or the template itself.
+	Schema string `json:"schema"`
+
+	// Data rendered by the template.
+	Data interface{} `json:"data"`
+}
+
+// Write writes the card for the data to the file given by DRONE_CARD_PATH.
+//
+// Nothing is written if DRONE_CARD_PATH is not set.
+func Write(schema string, data interface{}) error {
+	path := os.Getenv(PathEnv)
+	if path == "" {
+		return nil
+	}
+
+	return WriteFile(path, schema, data)
+}
+
+// WriteFile writes the card for the data to the file at path.
+//
+// If path is /dev/stdout the card is written to the step log using the
+// base64 encoded escape sequence Drone extracts cards from.
+func WriteFile(path, schema string, data interface{}) error {
+	b, err := json.Marshal(Card{
+		Schema: schema,
+		Data:   data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal card: %w", err)
+	}
+
+	if path == stdout {
+		return Encode(os.Stdout, b)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed with err %s to create %s directory for card file", err, dir)
+	}
+
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("failed to write card to card file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Encode writes the card document to w as a base64 encoded escape sequence.
+//
+// This is the format Drone extracts cards from when they are written to the
+// step log.
+func Encode(w io.Writer, card []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(card)
+
+	if _, err := fmt.Fprintf(w, "\u001B]1338;%s\u001B]0m\n", encoded); err != nil {
+		return fmt.Errorf("failed to write card: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package card
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test writing a layout card to DRONE_CARD_PATH
+func TestLayoutWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "card.json")
+	t.Setenv(PathEnv, path)
+
+	err := NewLayout("Published").
+		AddPipeline(drone.Pipeline{
+			Build: drone.Build{Number: 3, Link: "https://drone.example.com/3"},
+			Repo:  drone.Repo{Slug: "octocat/hello-world"},
+		}).
+		AddImages(drone.DockerArtifact{
+			Data: drone.Data{
+				Images: []drone.Image{{Image: "octocat/hello-world:latest", Digest: "sha256:1234"}},
+			},
+		}).
+		Write()
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	var card struct {
+		Schema string `json:"schema"`
+		Data   Layout `json:"data"`
+	}
+
+	assert.NoError(t, json.Unmarshal(b, &card))
+	assert.Equal(t, LayoutTemplate, card.Schema)
+	assert.Equal(t, "Published", card.Data.Title)
+	assert.Equal(t, []Fact{{Name: "Repository", Value: "octocat/hello-world"}, {Name: "Build", Value: "3"}}, card.Data.Facts)
+	assert.Equal(t, []Link{{Title: "Build", URL: "https://drone.example.com/3"}}, card.Data.Links)
+	assert.Equal(t, "sha256:1234", card.Data.Images[0].Digest)
+}
+
+// Test nothing is written without DRONE_CARD_PATH
+func TestWriteNoPath(t *testing.T) {
+	t.Setenv(PathEnv, "")
+
+	assert.NoError(t, Write("https://example.com/card.json", map[string]string{}))
+}
+
+// Test encoding a card for the step log
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.NoError(t, Encode(&buf, []byte(`{"schema":"s"}`)))
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "\u001B]1338;"))
+
+	encoded := strings.TrimSuffix(strings.TrimPrefix(out, "\u001B]1338;"), "\u001B]0m\n")
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"schema":"s"}`, string(decoded))
+}
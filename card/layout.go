@@ -0,0 +1,155 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package card
+
+import (
+	"strconv"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// LayoutTemplate is the adaptive card template for a Layout.
+const LayoutTemplate = `{
+  "type": "AdaptiveCard",
+  "$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+  "version": "1.5",
+  "body": [
+    {
+      "type": "TextBlock",
+      "$when": "${title != ''}",
+      "text": "${title}",
+      "weight": "bolder",
+      "size": "medium",
+      "wrap": true
+    },
+    {
+      "type": "FactSet",
+      "$when": "${count(facts) > 0}",
+      "facts": [
+        {
+          "$data": "${facts}",
+          "title": "${name}",
+          "value": "${value}"
+        }
+      ]
+    },
+    {
+      "type": "Container",
+      "$when": "${count(images) > 0}",
+      "items": [
+        {
+          "type": "TextBlock",
+          "text": "Images",
+          "weight": "bolder"
+        },
+        {
+          "type": "FactSet",
+          "facts": [
+            {
+              "$data": "${images}",
+              "title": "${image}",
+              "value": "${digest}"
+            }
+          ]
+        }
+      ]
+    }
+  ],
+  "actions": [
+    {
+      "$data": "${links}",
+      "type": "Action.OpenUrl",
+      "title": "${title}",
+      "url": "${url}"
+    }
+  ]
+}`
+
+type (
+	// Layout is the data for a card with a title, key/value facts, a list
+	// of images and links.
+	Layout struct {
+		Title  string        `json:"title"`
+		Facts  []Fact        `json:"facts"`
+		Images []drone.Image `json:"images"`
+		Links  []Link        `json:"links"`
+	}
+
+	// Fact is a key/value pair on a card.
+	Fact struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// Link is a link on a card.
+	Link struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+	}
+)
+
+// NewLayout creates a Layout with the title.
+func NewLayout(title string) *Layout {
+	return &Layout{
+		Title:  title,
+		Facts:  []Fact{},
+		Images: []drone.Image{},
+		Links:  []Link{},
+	}
+}
+
+// AddFact adds a key/value fact. Empty values are skipped.
+func (l *Layout) AddFact(name, value string) *Layout {
+	if value != "" {
+		l.Facts = append(l.Facts, Fact{Name: name, Value: value})
+	}
+
+	return l
+}
+
+// AddLink adds a link. Empty URLs are skipped.
+func (l *Layout) AddLink(title, url string) *Layout {
+	if url != "" {
+		l.Links = append(l.Links, Link{Title: title, URL: url})
+	}
+
+	return l
+}
+
+// AddImages adds the images from the docker artifact.
+func (l *Layout) AddImages(artifact drone.DockerArtifact) *Layout {
+	l.Images = append(l.Images, artifact.Data.Images...)
+
+	return l
+}
+
+// AddPipeline adds facts for the repository, build and commit along with
+// links to the build and commit.
+func (l *Layout) AddPipeline(p drone.Pipeline) *Layout {
+	l.AddFact("Repository", p.Repo.Slug)
+
+	if p.Build.Number != 0 {
+		l.AddFact("Build", strconv.Itoa(p.Build.Number))
+	}
+
+	l.AddFact("Event", p.Build.Event.String())
+	l.AddFact("Branch", p.Build.Branch)
+	l.AddFact("Tag", p.Build.Tag)
+	l.AddFact("Commit", p.Commit.SHA)
+	l.AddFact("Author", p.Commit.Author.Username)
+
+	l.AddLink("Build", p.Build.Link)
+	l.AddLink("Commit", p.Commit.Link)
+
+	return l
+}
+
+// Write writes the layout card to the file given by DRONE_CARD_PATH.
+//
+// Nothing is written if DRONE_CARD_PATH is not set.
+func (l *Layout) Write() error {
+	return Write(LayoutTemplate, l)
+}
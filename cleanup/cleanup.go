@@ -0,0 +1,41 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package cleanup tracks resources to release when the plugin exits.
+//
+// Helpers creating temporary files, such as credentials, register a function
+// removing them. The registered functions are run by errors.HandleExit so
+// they are called however the plugin exits.
+package cleanup
+
+import (
+	"sync"
+)
+
+var (
+	mu    sync.Mutex
+	funcs []func()
+)
+
+// Register adds the function to run when the plugin exits.
+func Register(fn func()) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	funcs = append(funcs, fn)
+}
+
+// Run runs the registered functions in the reverse order they were
+// registered. Each function is only run once.
+func Run() {
+	mu.Lock()
+	registered := funcs
+	funcs = nil
+	mu.Unlock()
+
+	for i := len(registered) - 1; i >= 0; i-- {
+		registered[i]()
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Command drone-plugin-env prints the pipeline environment a plugin built on
+// this library would see.
+//
+// The resolved drone.Pipeline, network settings, harness file locations and
+// plugin settings are printed as a table or JSON. Missing or inconsistent
+// values are reported and secrets are masked. It can run as a pipeline step
+// for debugging:
+//
+//	steps:
+//	- name: debug
+//	  image: plugins/drone-plugin-env
+//	  settings:
+//	    format: json
+package main
+
+import (
+	"os"
+
+	"github.com/drone-plugins/drone-plugin-lib/errors"
+	"github.com/drone-plugins/drone-plugin-lib/provider"
+	"github.com/drone-plugins/drone-plugin-lib/urfave"
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	if err := urfave.LoadEnvFiles(os.Args); err != nil {
+		errors.HandleExit(errors.ExitMessage(err))
+	}
+
+	if err := provider.LoadGitHubInputs(); err != nil {
+		errors.HandleExit(errors.ExitMessage(err))
+	}
+
+	errors.HandleExit(newApp().Run(os.Args))
+}
+
+// newApp creates the cli.App of the command.
+func newApp() *cli.App {
+	return &cli.App{
+		Name:   "drone-plugin-env",
+		Usage:  "print the pipeline environment a plugin would see",
+		Action: run,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Usage:   "output format (table, json)",
+				Value:   formatTable,
+				EnvVars: []string{"PLUGIN_FORMAT"},
+			},
+			&cli.BoolFlag{
+				Name:    "strict",
+				Usage:   "exit with an error when problems are found",
+				EnvVars: []string{"PLUGIN_STRICT"},
+			},
+		}, urfave.Flags()...),
+	}
+}
+
+// run prints the environment.
+func run(ctx *cli.Context) error {
+	urfave.LoggingFromContext(ctx)
+
+	report := newReport(ctx)
+
+	if err := report.write(ctx.App.Writer, ctx.String("format")); err != nil {
+		return errors.ExitMessage(err)
+	}
+
+	if ctx.Bool("strict") && len(report.Problems) != 0 {
+		return errors.ExitMessagef("found %d problems with the pipeline environment", len(report.Problems))
+	}
+
+	return nil
+}
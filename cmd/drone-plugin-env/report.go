@@ -0,0 +1,196 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/harness"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/drone-plugins/drone-plugin-lib/urfave"
+	"github.com/urfave/cli/v2"
+)
+
+type (
+	// report of the pipeline environment.
+	report struct {
+		Pipeline drone.Pipeline    `json:"pipeline"`
+		Network  network           `json:"network"`
+		Harness  []file            `json:"harness"`
+		Settings map[string]string `json:"settings"`
+		Problems []string          `json:"problems"`
+	}
+
+	// network settings of the drone.Network.
+	network struct {
+		SkipVerify            bool   `json:"skip_verify"`
+		DialTimeout           string `json:"dial_timeout"`
+		TLSHandshakeTimeout   string `json:"tls_handshake_timeout"`
+		ResponseHeaderTimeout string `json:"response_header_timeout"`
+		IdleConnTimeout       string `json:"idle_conn_timeout"`
+		Timeout               string `json:"timeout"`
+		MaxConnsPerHost       int    `json:"max_conns_per_host"`
+		HTTP2                 bool   `json:"http2"`
+	}
+
+	// file is a harness file location.
+	file struct {
+		Name   string `json:"name"`
+		Path   string `json:"path"`
+		Exists bool   `json:"exists"`
+	}
+)
+
+// newReport creates the report from the cli.Context.
+func newReport(ctx *cli.Context) *report {
+	pipeline := urfave.PipelineFromContext(ctx)
+	net := urfave.NetworkFromContext(ctx)
+
+	r := &report{
+		Pipeline: pipeline,
+		Network: network{
+			SkipVerify:            net.SkipVerify,
+			DialTimeout:           net.DialTimeout.String(),
+			TLSHandshakeTimeout:   net.TLSHandshakeTimeout.String(),
+			ResponseHeaderTimeout: net.ResponseHeaderTimeout.String(),
+			IdleConnTimeout:       net.IdleConnTimeout.String(),
+			Timeout:               net.Timeout.String(),
+			MaxConnsPerHost:       net.MaxConnsPerHost,
+			HTTP2:                 net.HTTP2,
+		},
+		Settings: settings(),
+	}
+
+	for _, name := range []string{
+		harness.DroneOutputFile,
+		harness.HarnessOutputSecretFile,
+		harness.MetadataFile,
+	} {
+		r.Harness = append(r.Harness, harnessFile(name))
+	}
+
+	r.Problems = problems(pipeline, r.Harness)
+
+	return r
+}
+
+// settings returns the PLUGIN_ variables with secret values masked.
+func settings() map[string]string {
+	values := map[string]string{}
+
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+
+		if !strings.HasPrefix(k, "PLUGIN_") {
+			continue
+		}
+
+		if urfave.IsSecretSetting(k) && v != "" {
+			secret.Register(v)
+		}
+
+		values[k] = v
+	}
+
+	return values
+}
+
+// harnessFile returns the location of the harness file.
+func harnessFile(name string) file {
+	f := file{
+		Name: name,
+		Path: os.Getenv(name),
+	}
+
+	if f.Path != "" {
+		_, err := os.Stat(f.Path)
+		f.Exists = err == nil
+	}
+
+	return f
+}
+
+// problems returns missing or inconsistent values within the environment.
+func problems(p drone.Pipeline, files []file) []string {
+	var found []string
+
+	add := func(msg string) {
+		found = append(found, msg)
+	}
+
+	if p.Repo.Slug == "" {
+		add("DRONE_REPO is not set")
+	} else if p.Repo.Owner != "" && p.Repo.Name != "" && p.Repo.Slug != p.Repo.Owner+"/"+p.Repo.Name {
+		add("DRONE_REPO does not match DRONE_REPO_OWNER and DRONE_REPO_NAME")
+	}
+
+	if p.Commit.SHA == "" {
+		add("DRONE_COMMIT_SHA is not set")
+	}
+
+	if p.Build.Number == 0 {
+		add("DRONE_BUILD_NUMBER is not set")
+	}
+
+	if p.Step.Name == "" {
+		add("DRONE_STEP_NAME is not set")
+	}
+
+	switch p.Build.Event {
+	case "":
+		add("DRONE_BUILD_EVENT is not set")
+	case drone.EventTag:
+		if p.Build.Tag == "" {
+			add("tag event with no DRONE_TAG")
+		}
+
+		if p.Commit.Ref != "" && !strings.HasPrefix(p.Commit.Ref, "refs/tags/") {
+			add("tag event with DRONE_COMMIT_REF not referencing a tag")
+		}
+	case drone.EventPullRequest:
+		if p.Build.PullRequest == 0 {
+			add("pull_request event with no DRONE_PULL_REQUEST")
+		}
+
+		if p.Build.SourceBranch == "" || p.Build.TargetBranch == "" {
+			add("pull_request event with no DRONE_SOURCE_BRANCH or DRONE_TARGET_BRANCH")
+		}
+	case drone.EventPromote, drone.EventRollback:
+		if p.Build.DeployTo == "" {
+			add(p.Build.Event.String() + " event with no DRONE_DEPLOY_TO")
+		}
+	default:
+		if p.Build.Tag != "" {
+			add(p.Build.Event.String() + " event with DRONE_TAG set")
+		}
+	}
+
+	if p.Build.Tag != "" && p.SemVer.Error != "" {
+		add("DRONE_TAG is not a semantic version: " + p.SemVer.Error)
+	}
+
+	if !p.Build.Finished.IsZero() && p.Build.Finished.Before(p.Build.Started) {
+		add("DRONE_BUILD_FINISHED is before DRONE_BUILD_STARTED")
+	}
+
+	for _, f := range files {
+		if f.Path == "" {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Dir(f.Path)); err != nil {
+			add(f.Name + " directory does not exist")
+		}
+	}
+
+	sort.Strings(found)
+
+	return found
+}
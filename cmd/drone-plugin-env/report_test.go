@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/drone-plugins/drone-plugin-lib/errors"
+	"github.com/drone-plugins/drone-plugin-lib/harness"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+// setEnv sets the environment of a Drone push build.
+func setEnv(t *testing.T) {
+	dronetest.ClearEnv(t)
+
+	env := map[string]string{
+		"DRONE":                 "true",
+		"DRONE_REPO":            "octocat/hello-world",
+		"DRONE_REPO_OWNER":      "octocat",
+		"DRONE_REPO_NAME":       "hello-world",
+		"DRONE_COMMIT_SHA":      "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		"DRONE_BUILD_NUMBER":    "42",
+		"DRONE_BUILD_EVENT":     "push",
+		"DRONE_BUILD_STARTED":   "1700000000",
+		"DRONE_STEP_NAME":       "publish",
+		harness.DroneOutputFile: filepath.Join(t.TempDir(), "output.env"),
+		"PLUGIN_PASSWORD":       "hunter2",
+		"PLUGIN_REPO":           "octocat/hello-world",
+	}
+
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+}
+
+// runReport runs the command with the arguments returning its output.
+func runReport(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	app := newApp()
+	app.Writer = &buf
+
+	err := app.Run(append([]string{"drone-plugin-env"}, args...))
+
+	return buf.String(), err
+}
+
+// Test printing the report as a table
+func TestReportTable(t *testing.T) {
+	defer secret.Reset()
+
+	setEnv(t)
+
+	out, err := runReport(t)
+	assert.NoError(t, err)
+
+	for _, section := range []string{"PIPELINE", "NETWORK", "HARNESS", "SETTINGS", "PROBLEMS"} {
+		assert.Contains(t, out, "\n"+section+"\n")
+	}
+
+	lines := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if fields := strings.Fields(line); len(fields) > 1 {
+			lines[fields[0]] = strings.Join(fields[1:], " ")
+		}
+	}
+
+	assert.Equal(t, "octocat/hello-world", lines["Repo.Slug"])
+	assert.Equal(t, "42", lines["Build.Number"])
+	assert.Equal(t, "2023-11-14T22:13:20Z", lines["Build.Started"])
+	assert.NotContains(t, lines, "Build.Finished")
+	assert.Equal(t, "30s", lines["DialTimeout"])
+	assert.Equal(t, "***", lines["PLUGIN_PASSWORD"])
+	assert.Equal(t, "octocat/hello-world", lines["PLUGIN_REPO"])
+	assert.Contains(t, out, "  none")
+	assert.NotContains(t, out, "hunter2")
+}
+
+// Test printing the report as JSON
+func TestReportJSON(t *testing.T) {
+	defer secret.Reset()
+
+	setEnv(t)
+	t.Setenv("DRONE_BUILD_EVENT", "tag")
+
+	out, err := runReport(t, "--format", "json")
+	assert.NoError(t, err)
+	assert.NotContains(t, out, "hunter2")
+
+	r := report{}
+	assert.NoError(t, json.Unmarshal([]byte(out), &r))
+	assert.Equal(t, "octocat/hello-world", r.Pipeline.Repo.Slug)
+	assert.Equal(t, "***", r.Settings["PLUGIN_PASSWORD"])
+	assert.True(t, r.Network.HTTP2)
+	assert.Equal(t, []string{"tag event with no DRONE_TAG"}, r.Problems)
+
+	if assert.Len(t, r.Harness, 3) {
+		assert.Equal(t, harness.DroneOutputFile, r.Harness[0].Name)
+		assert.False(t, r.Harness[0].Exists)
+	}
+}
+
+// Test strict mode fails when problems are found
+func TestReportStrict(t *testing.T) {
+	defer secret.Reset()
+
+	setEnv(t)
+
+	_, err := runReport(t, "--strict")
+	assert.NoError(t, err)
+
+	t.Setenv("DRONE_BUILD_FINISHED", "1600000000")
+	t.Setenv("DRONE_STEP_NAME", "")
+
+	out, err := runReport(t, "--strict")
+	assert.EqualError(t, err, "found 2 problems with the pipeline environment")
+	assert.Contains(t, out, "DRONE_BUILD_FINISHED is before DRONE_BUILD_STARTED")
+	assert.Contains(t, out, "DRONE_STEP_NAME is not set")
+
+	if e, ok := err.(errors.ExitCoder); assert.True(t, ok) {
+		assert.Equal(t, 1, e.Code())
+	}
+
+	_, err = runReport(t, "--format", "xml")
+	assert.EqualError(t, err, "unknown format xml")
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+)
+
+const (
+	// formatTable prints the report as a table.
+	formatTable = "table"
+
+	// formatJSON prints the report as JSON.
+	formatJSON = "json"
+)
+
+// write the report to w in the format with all secrets masked.
+func (r *report) write(w io.Writer, format string) error {
+	var buf bytes.Buffer
+
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	case formatTable:
+		r.table(&buf)
+	default:
+		return fmt.Errorf("unknown format %s", format)
+	}
+
+	_, err := io.WriteString(w, secret.Mask(buf.String()))
+
+	return err
+}
+
+// table writes the report as a table.
+func (r *report) table(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	section := func(name string) {
+		fmt.Fprintf(tw, "\n%s\n", strings.ToUpper(name))
+	}
+	row := func(key string, value interface{}) {
+		fmt.Fprintf(tw, "  %s\t%v\n", key, value)
+	}
+
+	section("pipeline")
+	flatten("", reflect.ValueOf(r.Pipeline), row)
+
+	section("network")
+	flatten("", reflect.ValueOf(r.Network), row)
+
+	section("harness")
+	for _, f := range r.Harness {
+		status := "missing"
+		if f.Path == "" {
+			status = "not set"
+		} else if f.Exists {
+			status = "exists"
+		}
+
+		row(f.Name, fmt.Sprintf("%s (%s)", f.Path, status))
+	}
+
+	section("settings")
+	keys := make([]string, 0, len(r.Settings))
+	for k := range r.Settings {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		row(k, r.Settings[k])
+	}
+
+	section("problems")
+	if len(r.Problems) == 0 {
+		row("none", "")
+	}
+
+	for _, p := range r.Problems {
+		fmt.Fprintf(tw, "  %s\n", p)
+	}
+
+	tw.Flush()
+}
+
+// flatten calls row for every field within the struct value.
+func flatten(prefix string, v reflect.Value, row func(string, interface{})) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		name := field.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		value := v.Field(i)
+
+		switch actual := value.Interface().(type) {
+		case time.Time:
+			if actual.IsZero() {
+				row(name, "")
+			} else {
+				row(name, actual.UTC().Format(time.RFC3339))
+			}
+
+			continue
+		case fmt.Stringer:
+			if value.Kind() != reflect.Struct {
+				row(name, actual.String())
+				continue
+			}
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			flatten(name, value, row)
+		case reflect.Slice:
+			parts := make([]string, 0, value.Len())
+			for j := 0; j < value.Len(); j++ {
+				parts = append(parts, fmt.Sprint(value.Index(j).Interface()))
+			}
+
+			row(name, strings.Join(parts, ","))
+		default:
+			row(name, value.Interface())
+		}
+	}
+}
@@ -12,57 +12,114 @@ import (
 // Build represents a build of a repository.
 type Build struct {
 	// Branch defines the branch name of the build.
-	Branch string
+	Branch string `json:"branch" yaml:"branch"`
 
 	// PullRequest number of the build.
-	PullRequest int
+	PullRequest int `json:"pull_request" yaml:"pull_request"`
 
 	// Tag of the build.
-	Tag string
+	Tag string `json:"tag" yaml:"tag"`
 
 	// SourceBranch for the pull request.
-	SourceBranch string
+	SourceBranch string `json:"source_branch" yaml:"source_branch"`
 
 	// TargetBranch for the pull request.
-	TargetBranch string
+	TargetBranch string `json:"target_branch" yaml:"target_branch"`
 
 	// Number for the build.
-	Number int
+	Number int `json:"number" yaml:"number"`
 
 	// Parent build number for the build.
-	Parent int
+	Parent int `json:"parent" yaml:"parent"`
 
 	// Event that triggered the build.
-	Event string
+	Event Event `json:"event" yaml:"event"`
 
 	// Action that triggered the build. This value is used to differentiate
-	// bettween a pull request being opened vs synchronized.
-	Action string
+	// between a pull request being opened vs synchronized.
+	Action Action `json:"action" yaml:"action"`
 
 	// Status of the build.
-	Status string
+	Status Status `json:"status" yaml:"status"`
 
 	// Link to the build.
-	Link string
+	Link string `json:"link" yaml:"link"`
 
 	// Created time of the build.
-	Created time.Time
+	Created time.Time `json:"created" yaml:"created"`
 
 	// Started time of the build.
-	Started time.Time
+	Started time.Time `json:"started" yaml:"started"`
 
 	// Finished time of the build.
-	Finished time.Time
+	Finished time.Time `json:"finished" yaml:"finished"`
 
 	// DeployTo the environment.
-	DeployTo string
+	DeployTo string `json:"deploy_to" yaml:"deploy_to"`
 
 	// DeployID for the environment.
-	DeployID int
+	DeployID int `json:"deploy_id" yaml:"deploy_id"`
 
 	// FailedStages of the build.
-	FailedStages []string
+	FailedStages []string `json:"failed_stages" yaml:"failed_stages"`
 
 	// FailedSteps of the build.
-	FailedSteps []string
+	FailedSteps []string `json:"failed_steps" yaml:"failed_steps"`
+
+	// PreviousNumber of the previous build.
+	PreviousNumber int `json:"previous_number" yaml:"previous_number"`
+
+	// PreviousStatus of the previous build.
+	PreviousStatus Status `json:"previous_status" yaml:"previous_status"`
+
+	// PreviousSHA of the commit of the previous build.
+	PreviousSHA string `json:"previous_sha" yaml:"previous_sha"`
+}
+
+// Transition returns the transition from the status of the previous build
+// to the status of the build.
+func (b Build) Transition() Transition {
+	return StatusTransition(b.PreviousStatus, b.Status)
+}
+
+// IsPush checks if the build was triggered by a push.
+func (b Build) IsPush() bool {
+	return b.Event == EventPush
+}
+
+// IsPullRequest checks if the build was triggered by a pull request.
+func (b Build) IsPullRequest() bool {
+	return b.Event == EventPullRequest
+}
+
+// IsTag checks if the build was triggered by a tag.
+func (b Build) IsTag() bool {
+	return b.Event == EventTag
+}
+
+// IsPromotion checks if the build was triggered by a promotion.
+func (b Build) IsPromotion() bool {
+	return b.Event == EventPromote
+}
+
+// IsRollback checks if the build was triggered by a rollback.
+func (b Build) IsRollback() bool {
+	return b.Event == EventRollback
+}
+
+// IsDeployment checks if the build was triggered by a promotion or
+// rollback.
+func (b Build) IsDeployment() bool {
+	return b.IsPromotion() || b.IsRollback()
+}
+
+// IsCron checks if the build was triggered by a schedule.
+func (b Build) IsCron() bool {
+	return b.Event == EventCron
+}
+
+// IsCustom checks if the build was triggered through the API or user
+// interface.
+func (b Build) IsCustom() bool {
+	return b.Event == EventCustom
 }
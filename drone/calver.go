@@ -11,24 +11,24 @@ package drone
 // a calendar version then the value will be empty.
 type CalVer struct {
 	// Version is the full calendar version.
-	Version string
+	Version string `json:"version" yaml:"version"`
 
 	// Major is the major version.
-	Major string
+	Major string `json:"major" yaml:"major"`
 
 	// Minor is the minor version.
-	Minor string
+	Minor string `json:"minor" yaml:"minor"`
 
 	// Micro is the micro version.
-	Micro string
+	Micro string `json:"micro" yaml:"micro"`
 
 	// Modifier is a modifier for the version.
-	Modifier string
+	Modifier string `json:"modifier" yaml:"modifier"`
 
 	// Short is the short version.
 	//
 	// This does not include the modifier.
-	Short string
+	Short string `json:"short" yaml:"short"`
 }
 
 func (c CalVer) String() string {
@@ -11,49 +11,49 @@ type (
 	// Commit represents the current commit being built.
 	Commit struct {
 		// SHA for the current commit.
-		SHA string
+		SHA string `json:"sha" yaml:"sha"`
 
 		// Before contains the commit sha before the patch is applied.
-		Before string
+		Before string `json:"before" yaml:"before"`
 
 		// After contains the commit sha after the patch is applied.
-		After string
+		After string `json:"after" yaml:"after"`
 
 		// Ref for the current commit.
-		Ref string
+		Ref string `json:"ref" yaml:"ref"`
 
 		// Branch target for the push or pull request. This may be empty for
 		// tag events.
-		Branch string
+		Branch string `json:"branch" yaml:"branch"`
 
 		// Link to the commit or object in the source control management system.
-		Link string
+		Link string `json:"link" yaml:"link"`
 
 		// Message for the current commit.
-		Message Message
+		Message Message `json:"message" yaml:"message"`
 
 		// Author of the commit.
-		Author Author
+		Author Author `json:"author" yaml:"author"`
 	}
 
 	// Author of a Commit.
 	Author struct {
 		// Username of the Commit author.
-		Username string
+		Username string `json:"username" yaml:"username"`
 		// Name of the Commit author.
-		Name string
+		Name string `json:"name" yaml:"name"`
 		// Email for the Commit author.
-		Email string
+		Email string `json:"email" yaml:"email"`
 		// Avatar for the Commit author.
-		Avatar string
+		Avatar string `json:"avatar" yaml:"avatar"`
 	}
 
 	// Message for a Commit.
 	Message struct {
 		// Title for the Commit.
-		Title string
+		Title string `json:"title" yaml:"title"`
 		// Body of the Commit message.
-		Body string
+		Body string `json:"body" yaml:"body"`
 	}
 )
 
@@ -0,0 +1,166 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environ returns the DRONE_ environment variables for the pipeline in the
+// form "key=value".
+//
+// The variables use the same names the urfave flags read, including any
+// aliases, so the result can be passed to a subprocess or child plugin.
+// Empty values are omitted.
+func (p Pipeline) Environ() []string {
+	env := p.EnvironMap()
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	environ := make([]string, 0, len(keys))
+	for _, k := range keys {
+		environ = append(environ, k+"="+env[k])
+	}
+
+	return environ
+}
+
+// EnvironMap returns the DRONE_ environment variables for the pipeline.
+func (p Pipeline) EnvironMap() map[string]string {
+	env := map[string]string{}
+
+	set := func(key, value string, aliases ...string) {
+		if value == "" {
+			return
+		}
+
+		env[key] = value
+
+		for _, alias := range aliases {
+			env[alias] = value
+		}
+	}
+	setInt := func(key string, value int) {
+		if value != 0 {
+			env[key] = strconv.Itoa(value)
+		}
+	}
+	setTime := func(key string, value time.Time) {
+		if !value.IsZero() {
+			env[key] = strconv.FormatInt(value.Unix(), 10)
+		}
+	}
+
+	env["CI"] = "true"
+	env["DRONE"] = "true"
+
+	set("DRONE_BRANCH", p.Build.Branch)
+	setInt("DRONE_PULL_REQUEST", p.Build.PullRequest)
+	set("DRONE_TAG", p.Build.Tag)
+	set("DRONE_SOURCE_BRANCH", p.Build.SourceBranch)
+	set("DRONE_TARGET_BRANCH", p.Build.TargetBranch)
+	setInt("DRONE_BUILD_NUMBER", p.Build.Number)
+	setInt("DRONE_BUILD_PARENT", p.Build.Parent)
+	set("DRONE_BUILD_EVENT", p.Build.Event.String())
+	set("DRONE_BUILD_ACTION", p.Build.Action.String())
+	set("DRONE_BUILD_STATUS", p.Build.Status.String())
+	set("DRONE_BUILD_LINK", p.Build.Link)
+	setTime("DRONE_BUILD_CREATED", p.Build.Created)
+	setTime("DRONE_BUILD_STARTED", p.Build.Started)
+	setTime("DRONE_BUILD_FINISHED", p.Build.Finished)
+	set("DRONE_DEPLOY_TO", p.Build.DeployTo)
+	setInt("DRONE_DEPLOY_ID", p.Build.DeployID)
+	set("DRONE_FAILED_STAGES", strings.Join(p.Build.FailedStages, ","))
+	set("DRONE_FAILED_STEPS", strings.Join(p.Build.FailedSteps, ","))
+	setInt("DRONE_PREV_BUILD_NUMBER", p.Build.PreviousNumber)
+	set("DRONE_PREV_BUILD_STATUS", p.Build.PreviousStatus.String())
+	set("DRONE_PREV_COMMIT_SHA", p.Build.PreviousSHA)
+
+	set("DRONE_REPO", p.Repo.Slug)
+	set("DRONE_REPO_SCM", p.Repo.SCM)
+	set("DRONE_REPO_OWNER", p.Repo.Owner, "DRONE_REPO_NAMESPACE")
+	set("DRONE_REPO_NAME", p.Repo.Name)
+	set("DRONE_REPO_LINK", p.Repo.Link)
+	set("DRONE_REPO_BRANCH", p.Repo.Branch)
+	set("DRONE_GIT_HTTP_URL", p.Repo.HTTPURL, "DRONE_REMOTE_URL")
+	set("DRONE_GIT_SSH_URL", p.Repo.SSHURL)
+	set("DRONE_REPO_VISIBILITY", p.Repo.Visibility)
+	env["DRONE_REPO_PRIVATE"] = strconv.FormatBool(p.Repo.Private)
+
+	set("DRONE_COMMIT_SHA", p.Commit.SHA, "DRONE_COMMIT")
+	set("DRONE_COMMIT_BEFORE", p.Commit.Before)
+	set("DRONE_COMMIT_AFTER", p.Commit.After)
+	set("DRONE_COMMIT_REF", p.Commit.Ref)
+	set("DRONE_COMMIT_BRANCH", p.Commit.Branch)
+	set("DRONE_COMMIT_LINK", p.Commit.Link)
+	set("DRONE_COMMIT_MESSAGE", p.Commit.Message.String())
+	set("DRONE_COMMIT_AUTHOR", p.Commit.Author.Username)
+	set("DRONE_COMMIT_AUTHOR_NAME", p.Commit.Author.Name)
+	set("DRONE_COMMIT_AUTHOR_EMAIL", p.Commit.Author.Email)
+	set("DRONE_COMMIT_AUTHOR_AVATAR", p.Commit.Author.Avatar)
+
+	set("DRONE_STAGE_KIND", p.Stage.Kind)
+	set("DRONE_STAGE_TYPE", p.Stage.Type)
+	set("DRONE_STAGE_NAME", p.Stage.Name)
+	setInt("DRONE_STAGE_NUMBER", p.Stage.Number)
+	set("DRONE_STAGE_MACHINE", p.Stage.Machine)
+	set("DRONE_STAGE_OS", p.Stage.OS)
+	set("DRONE_STAGE_ARCH", p.Stage.Arch)
+	set("DRONE_STAGE_VARIANT", p.Stage.Variant)
+	set("DRONE_STAGE_VERSION", p.Stage.Version)
+	set("DRONE_STAGE_STATUS", p.Stage.Status.String())
+	setTime("DRONE_STAGE_STARTED", p.Stage.Started)
+	setTime("DRONE_STAGE_FINISHED", p.Stage.Finished)
+	set("DRONE_STAGE_DEPENDS_ON", strings.Join(p.Stage.DependsOn, ","))
+
+	set("DRONE_STEP_NAME", p.Step.Name)
+	setInt("DRONE_STEP_NUMBER", p.Step.Number)
+
+	set("DRONE_SEMVER", p.SemVer.Version)
+	set("DRONE_SEMVER_MAJOR", p.SemVer.Major)
+	set("DRONE_SEMVER_MINOR", p.SemVer.Minor)
+	set("DRONE_SEMVER_PATCH", p.SemVer.Patch)
+	set("DRONE_SEMVER_PRERELEASE", p.SemVer.Prerelease)
+	set("DRONE_SEMVER_BUILD", p.SemVer.Build)
+	set("DRONE_SEMVER_SHORT", p.SemVer.Short)
+	set("DRONE_SEMVER_ERROR", p.SemVer.Error)
+
+	set("DRONE_CALVER", p.CalVer.Version)
+	set("DRONE_CALVER_MAJOR", p.CalVer.Major)
+	set("DRONE_CALVER_MINOR", p.CalVer.Minor)
+	set("DRONE_CALVER_MICRO", p.CalVer.Micro)
+	set("DRONE_CALVER_MODIFIER", p.CalVer.Modifier)
+	set("DRONE_CALVER_SHORT", p.CalVer.Short)
+
+	set("DRONE_SYSTEM_PROTO", p.System.Proto)
+	set("DRONE_SYSTEM_HOST", p.System.Host, "DRONE_SYSTEM_HOSTNAME")
+	set("DRONE_SYSTEM_VERSION", p.System.Version)
+
+	set("DRONE_NETRC_MACHINE", p.Netrc.Machine)
+	set("DRONE_NETRC_USERNAME", p.Netrc.Username)
+	set("DRONE_NETRC_PASSWORD", p.Netrc.Password)
+
+	set("DRONE_WORKSPACE", p.Workspace.Dir)
+	set("DRONE_WORKSPACE_BASE", p.Workspace.Base)
+	set("DRONE_WORKSPACE_PATH", p.Workspace.Path)
+
+	set("DRONE_PULL_REQUEST_TITLE", p.PullRequest.Title)
+	set("DRONE_PULL_REQUEST_LINK", p.PullRequest.Link)
+	set("DRONE_PULL_REQUEST_SOURCE_REPO", p.PullRequest.SourceRepo)
+	set("DRONE_PULL_REQUEST_TARGET_REPO", p.PullRequest.TargetRepo)
+	set("DRONE_PULL_REQUEST_HEAD_SHA", p.PullRequest.HeadSHA)
+	set("DRONE_PULL_REQUEST_BASE_SHA", p.PullRequest.BaseSHA)
+
+	return env
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+import "fmt"
+
+// Event that triggered the build.
+type Event string
+
+const (
+	// EventPush is a push to a branch.
+	EventPush Event = "push"
+
+	// EventPullRequest is a pull request being opened or updated.
+	EventPullRequest Event = "pull_request"
+
+	// EventTag is a tag being created.
+	EventTag Event = "tag"
+
+	// EventPromote is a build being promoted to an environment.
+	EventPromote Event = "promote"
+
+	// EventRollback is a build being rolled back to an environment.
+	EventRollback Event = "rollback"
+
+	// EventCron is a scheduled build.
+	EventCron Event = "cron"
+
+	// EventCustom is a build triggered through the API or user interface.
+	EventCustom Event = "custom"
+)
+
+// events are the known events.
+var events = []Event{
+	EventPush,
+	EventPullRequest,
+	EventTag,
+	EventPromote,
+	EventRollback,
+	EventCron,
+	EventCustom,
+}
+
+// ParseEvent parses the event.
+//
+// An error is returned along with the event if it is unknown. An empty
+// event is valid.
+func ParseEvent(s string) (Event, error) {
+	event := Event(s)
+	if event != "" && !contains(events, event) {
+		return event, fmt.Errorf("unknown event %q", s)
+	}
+
+	return event, nil
+}
+
+func (e Event) String() string {
+	return string(e)
+}
+
+// Action that triggered the build.
+type Action string
+
+const (
+	// ActionOpened is a pull request being opened.
+	ActionOpened Action = "opened"
+
+	// ActionSynchronized is a pull request being updated with new commits.
+	ActionSynchronized Action = "synchronized"
+
+	// ActionReopened is a pull request being reopened.
+	ActionReopened Action = "reopened"
+
+	// ActionClosed is a pull request being closed.
+	ActionClosed Action = "closed"
+
+	// ActionCreated is a branch or tag being created.
+	ActionCreated Action = "created"
+
+	// ActionDeleted is a branch or tag being deleted.
+	ActionDeleted Action = "deleted"
+)
+
+// actions are the known actions.
+var actions = []Action{
+	ActionOpened,
+	ActionSynchronized,
+	ActionReopened,
+	ActionClosed,
+	ActionCreated,
+	ActionDeleted,
+}
+
+// ParseAction parses the action.
+//
+// An error is returned along with the action if it is unknown. An empty
+// action is valid.
+func ParseAction(s string) (Action, error) {
+	action := Action(s)
+	if action != "" && !contains(actions, action) {
+		return action, fmt.Errorf("unknown action %q", s)
+	}
+
+	return action, nil
+}
+
+func (a Action) String() string {
+	return string(a)
+}
+
+// contains checks if the values contain v.
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+// HostKind is the kind of CI system the plugin is running within.
+type HostKind string
+
+const (
+	// HostDrone is Drone.
+	HostDrone HostKind = "drone"
+
+	// HostHarness is Harness CI.
+	HostHarness HostKind = "harness"
+
+	// HostGitHub is GitHub Actions.
+	HostGitHub HostKind = "github"
+
+	// HostWoodpecker is Woodpecker CI.
+	HostWoodpecker HostKind = "woodpecker"
+
+	// HostGitLab is GitLab CI.
+	HostGitLab HostKind = "gitlab"
+
+	// HostLocal is running outside of a CI system.
+	HostLocal HostKind = "local"
+)
+
+// droneSkipExitCode is the exit code Drone uses to skip the remaining steps
+// of the pipeline without failing it.
+const droneSkipExitCode = 78
+
+// Capabilities describes the features the host CI supports.
+type Capabilities struct {
+	// Outputs can be exported to later steps through the DRONE_OUTPUT file.
+	Outputs bool
+
+	// Cards are rendered from the DRONE_CARD_PATH file.
+	Cards bool
+
+	// StepSummary is rendered from the GITHUB_STEP_SUMMARY file.
+	StepSummary bool
+
+	// SkipExitCode is the exit code skipping the remaining steps of the
+	// pipeline without failing it, zero if not supported.
+	SkipExitCode int
+}
+
+func (k HostKind) String() string {
+	return string(k)
+}
+
+// Capabilities returns the features the host CI supports.
+func (k HostKind) Capabilities() Capabilities {
+	switch k {
+	case HostDrone:
+		return Capabilities{Cards: true, SkipExitCode: droneSkipExitCode}
+	case HostHarness:
+		return Capabilities{Outputs: true}
+	case HostGitHub:
+		return Capabilities{StepSummary: true}
+	}
+
+	return Capabilities{}
+}
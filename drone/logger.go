@@ -0,0 +1,83 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+)
+
+// loggerKey is the context key for the logger.
+type loggerKey struct{}
+
+// WithLogger returns a copy of the context carrying the logger.
+func WithLogger(ctx context.Context, logger *logrus.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger carried by the context.
+//
+// If the context does not carry a logger the logrus standard logger is
+// returned.
+func LoggerFromContext(ctx context.Context) *logrus.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*logrus.Logger); ok && logger != nil {
+			return logger
+		}
+	}
+
+	return logrus.StandardLogger()
+}
+
+// HasLogger checks if the context carries a logger.
+func HasLogger(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	logger, ok := ctx.Value(loggerKey{}).(*logrus.Logger)
+
+	return ok && logger != nil
+}
+
+// slogKey is the context key for the slog logger.
+type slogKey struct{}
+
+// WithSlog returns a copy of the context carrying the slog logger.
+func WithSlog(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, slogKey{}, logger)
+}
+
+// SlogFromContext returns the slog logger carried by the context.
+//
+// If the context does not carry a slog logger the default slog logger is
+// returned.
+func SlogFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := lookupSlog(ctx); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// HasSlog checks if the context carries a slog logger.
+func HasSlog(ctx context.Context) bool {
+	_, ok := lookupSlog(ctx)
+	return ok
+}
+
+// lookupSlog returns the slog logger carried by the context if present.
+func lookupSlog(ctx context.Context) (*slog.Logger, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	logger, ok := ctx.Value(slogKey{}).(*slog.Logger)
+
+	return logger, ok && logger != nil
+}
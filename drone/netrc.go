@@ -0,0 +1,25 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+// Netrc represents the credentials for the git remote of the repository.
+type Netrc struct {
+	// Machine for the remote host name.
+	Machine string `json:"machine" yaml:"machine"`
+
+	// Username for the remote.
+	Username string `json:"username" yaml:"username"`
+
+	// Password or token for the remote.
+	//
+	// The password is never serialized so it doesn't end up in recordings
+	// or reports.
+	Password string `json:"-" yaml:"-"`
+}
+
+func (n Netrc) String() string {
+	return n.Machine
+}
@@ -7,7 +7,11 @@ package drone
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 // Network contains options for connecting to the network.
@@ -21,6 +25,43 @@ type Network struct {
 	/// Whether SSL verification is skipped
 	SkipVerify bool
 
+	// DialTimeout is the maximum amount of time a dial will wait for a
+	// connect to complete.
+	DialTimeout time.Duration
+
+	// TLSHandshakeTimeout is the maximum amount of time to wait for a TLS
+	// handshake.
+	TLSHandshakeTimeout time.Duration
+
+	// ResponseHeaderTimeout is the amount of time to wait for the response
+	// headers after fully writing the request. Zero means no timeout.
+	ResponseHeaderTimeout time.Duration
+
+	// IdleConnTimeout is the maximum amount of time an idle connection will
+	// remain idle before closing itself.
+	IdleConnTimeout time.Duration
+
+	// Timeout is the overall time limit for a request made by the Client,
+	// including reading the response body. Zero means no timeout.
+	Timeout time.Duration
+
+	// MaxConnsPerHost limits the number of connections per host. Zero means
+	// no limit.
+	MaxConnsPerHost int
+
+	// HTTP2 is whether the Client attempts to use HTTP/2.
+	HTTP2 bool
+
 	// Client for making network requests.
 	Client *http.Client
+
+	// Logger for the network requests.
+	//
+	// This is the logger carried by Context.
+	Logger *logrus.Logger
+
+	// Slog is the slog logger for the network requests.
+	//
+	// This is only set when a slog logger is carried by Context.
+	Slog *slog.Logger
 }
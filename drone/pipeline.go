@@ -9,12 +9,45 @@ package drone
 //
 // Represents the full Drone environment that the plugin is executing in.
 type Pipeline struct {
-	Build  Build
-	Repo   Repo
-	Commit Commit
-	Stage  Stage
-	Step   Step
-	SemVer SemVer
-	CalVer CalVer
-	System System
+	Build       Build       `json:"build" yaml:"build"`
+	Repo        Repo        `json:"repo" yaml:"repo"`
+	Commit      Commit      `json:"commit" yaml:"commit"`
+	Stage       Stage       `json:"stage" yaml:"stage"`
+	Step        Step        `json:"step" yaml:"step"`
+	SemVer      SemVer      `json:"semver" yaml:"semver"`
+	CalVer      CalVer      `json:"calver" yaml:"calver"`
+	System      System      `json:"system" yaml:"system"`
+	Netrc       Netrc       `json:"netrc" yaml:"netrc"`
+	Workspace   Workspace   `json:"workspace" yaml:"workspace"`
+	PullRequest PullRequest `json:"pull_request" yaml:"pull_request"`
+}
+
+// FillPullRequest fills the missing PullRequest values of a pull request
+// build.
+//
+// The number and branches default to the ones of the Build, the target
+// repository to the Repo slug, and the head sha and link to the ones of the
+// Commit. Nothing is filled if the build is not for a pull request.
+func (p *Pipeline) FillPullRequest() {
+	if p.Build.PullRequest == 0 {
+		return
+	}
+
+	pr := &p.PullRequest
+
+	if pr.Number == 0 {
+		pr.Number = p.Build.PullRequest
+	}
+
+	fill := func(dst *string, value string) {
+		if *dst == "" {
+			*dst = value
+		}
+	}
+
+	fill(&pr.SourceBranch, p.Build.SourceBranch)
+	fill(&pr.TargetBranch, p.Build.TargetBranch)
+	fill(&pr.TargetRepo, p.Repo.Slug)
+	fill(&pr.HeadSHA, p.Commit.SHA)
+	fill(&pr.Link, p.Commit.Link)
 }
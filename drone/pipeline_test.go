@@ -0,0 +1,292 @@
+package drone
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// testPipeline returns a pipeline with every field populated.
+func testPipeline() Pipeline {
+	created := time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)
+	started := created.Add(time.Minute)
+	finished := started.Add(time.Hour)
+
+	return Pipeline{
+		Build: Build{
+			Branch:         "main",
+			PullRequest:    42,
+			Tag:            "v1.2.3",
+			SourceBranch:   "feature",
+			TargetBranch:   "main",
+			Number:         7,
+			Parent:         6,
+			Event:          EventPush,
+			Action:         ActionOpened,
+			Status:         StatusSuccess,
+			Link:           "https://drone.example.com/octocat/hello-world/7",
+			Created:        created,
+			Started:        started,
+			Finished:       finished,
+			DeployTo:       "production",
+			DeployID:       3,
+			FailedStages:   []string{"build"},
+			FailedSteps:    []string{"test", "lint"},
+			PreviousNumber: 6,
+			PreviousStatus: StatusFailure,
+			PreviousSHA:    "762941318ee16e59dabbacb1b4049eec22f0d303",
+		},
+		Repo: Repo{
+			Slug:       "octocat/hello-world",
+			SCM:        "git",
+			Owner:      "octocat",
+			Name:       "hello-world",
+			Link:       "https://github.com/octocat/hello-world",
+			Branch:     "main",
+			HTTPURL:    "https://github.com/octocat/hello-world.git",
+			SSHURL:     "git@github.com:octocat/hello-world.git",
+			Visibility: "private",
+			Private:    true,
+		},
+		Commit: Commit{
+			SHA:     "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			Before:  "762941318ee16e59dabbacb1b4049eec22f0d303",
+			After:   "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			Ref:     "refs/heads/main",
+			Branch:  "main",
+			Link:    "https://github.com/octocat/hello-world/commit/7fd1a60",
+			Message: ParseMessage("Update README\n\nAdd usage section"),
+			Author: Author{
+				Username: "octocat",
+				Name:     "The Octocat",
+				Email:    "octocat@github.com",
+				Avatar:   "https://avatars.githubusercontent.com/u/583231",
+			},
+		},
+		Stage: Stage{
+			Kind:      "pipeline",
+			Type:      "docker",
+			Name:      "default",
+			Number:    1,
+			Machine:   "runner",
+			OS:        "linux",
+			Arch:      "arm64",
+			Variant:   "v8",
+			Version:   "1",
+			Status:    StatusSuccess,
+			Started:   started,
+			Finished:  finished,
+			DependsOn: []string{"setup"},
+		},
+		Step: Step{
+			Name:   "publish",
+			Number: 2,
+		},
+		SemVer: SemVer{
+			Version:    "1.2.3-rc.1+build.5",
+			Major:      "1",
+			Minor:      "2",
+			Patch:      "3",
+			Prerelease: "rc.1",
+			Build:      "build.5",
+			Short:      "1.2.3",
+		},
+		CalVer: CalVer{
+			Version:  "20.01.1-beta",
+			Major:    "20",
+			Minor:    "01",
+			Micro:    "1",
+			Modifier: "beta",
+			Short:    "20.01.1",
+		},
+		System: System{
+			Proto:   "https",
+			Host:    "drone.example.com",
+			Version: "2.0.0",
+			Kind:    HostDrone,
+		},
+		Netrc: Netrc{
+			Machine:  "github.com",
+			Username: "octocat",
+		},
+		Workspace: Workspace{
+			Dir:  "/drone/src",
+			Base: "/drone",
+			Path: "src",
+		},
+		PullRequest: PullRequest{
+			Number:       42,
+			Title:        "Add usage section",
+			Link:         "https://github.com/octocat/hello-world/pull/42",
+			SourceRepo:   "hubot/hello-world",
+			TargetRepo:   "octocat/hello-world",
+			SourceBranch: "feature",
+			TargetBranch: "main",
+			HeadSHA:      "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			BaseSHA:      "762941318ee16e59dabbacb1b4049eec22f0d303",
+		},
+	}
+}
+
+// Test the pipeline round trips through JSON
+func TestPipelineJSON(t *testing.T) {
+	pipeline := testPipeline()
+
+	b, err := json.Marshal(pipeline)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"pull_request":42`)
+	assert.Contains(t, string(b), `"http_url":"https://github.com/octocat/hello-world.git"`)
+
+	var decoded Pipeline
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, pipeline, decoded)
+}
+
+// Test the netrc password is never serialized
+func TestPipelineNetrcPassword(t *testing.T) {
+	pipeline := testPipeline()
+	pipeline.Netrc.Password = "hunter2"
+
+	b, err := json.Marshal(pipeline)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "hunter2")
+
+	b, err = yaml.Marshal(pipeline)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "hunter2")
+}
+
+// Test the pipeline round trips through YAML
+func TestPipelineYAML(t *testing.T) {
+	pipeline := testPipeline()
+
+	b, err := yaml.Marshal(pipeline)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "deploy_to: production")
+
+	var decoded Pipeline
+	assert.NoError(t, yaml.Unmarshal(b, &decoded))
+	assert.Equal(t, pipeline, decoded)
+}
+
+// Test the environment variables for the pipeline
+func TestPipelineEnviron(t *testing.T) {
+	env := map[string]string{}
+	for _, kv := range testPipeline().Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		env[k] = v
+	}
+
+	assert.Equal(t, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", env["DRONE_COMMIT"])
+	assert.Equal(t, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", env["DRONE_COMMIT_SHA"])
+	assert.Equal(t, "Update README\n\nAdd usage section", env["DRONE_COMMIT_MESSAGE"])
+	assert.Equal(t, "test,lint", env["DRONE_FAILED_STEPS"])
+	assert.Equal(t, "1577872860", env["DRONE_BUILD_STARTED"])
+	assert.Equal(t, "true", env["DRONE_REPO_PRIVATE"])
+}
+
+// Test the transitions between build statuses
+func TestBuildTransition(t *testing.T) {
+	tests := []struct {
+		previous, current Status
+		expected          Transition
+	}{
+		{StatusFailure, StatusSuccess, TransitionFixed},
+		{StatusSuccess, StatusFailure, TransitionBroken},
+		{StatusError, StatusFailure, TransitionStillFailing},
+		{StatusSuccess, StatusSuccess, TransitionStillPassing},
+		{"", StatusSuccess, TransitionUnknown},
+		{StatusSuccess, StatusRunning, TransitionUnknown},
+	}
+
+	for _, test := range tests {
+		build := Build{PreviousStatus: test.previous, Status: test.current}
+		assert.Equal(t, test.expected, build.Transition(), test.previous.String()+" -> "+test.current.String())
+	}
+}
+
+// Test detecting pull requests from forks
+func TestPullRequestIsFromFork(t *testing.T) {
+	assert.True(t, testPipeline().PullRequest.IsFromFork())
+	assert.False(t, PullRequest{SourceRepo: "Octocat/Hello-World", TargetRepo: "octocat/hello-world"}.IsFromFork())
+	assert.False(t, PullRequest{TargetRepo: "octocat/hello-world"}.IsFromFork())
+}
+
+// Test filling the pull request from the build
+func TestPipelineFillPullRequest(t *testing.T) {
+	p := Pipeline{
+		Build: Build{
+			PullRequest:  42,
+			SourceBranch: "feature",
+			TargetBranch: "main",
+		},
+		Repo:        Repo{Slug: "octocat/hello-world"},
+		Commit:      Commit{SHA: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", Link: "https://github.com/octocat/hello-world/commit/7fd1a60"},
+		PullRequest: PullRequest{Link: "https://github.com/octocat/hello-world/pull/42"},
+	}
+
+	p.FillPullRequest()
+
+	assert.Equal(t, PullRequest{
+		Number:       42,
+		Link:         "https://github.com/octocat/hello-world/pull/42",
+		TargetRepo:   "octocat/hello-world",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		HeadSHA:      "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	}, p.PullRequest)
+
+	p = Pipeline{Repo: Repo{Slug: "octocat/hello-world"}}
+	p.FillPullRequest()
+	assert.Equal(t, PullRequest{}, p.PullRequest)
+}
+
+// Test parsing events, actions and statuses
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent("pull_request")
+	assert.NoError(t, err)
+	assert.Equal(t, EventPullRequest, event)
+
+	event, err = ParseEvent("")
+	assert.NoError(t, err)
+	assert.Equal(t, Event(""), event)
+
+	event, err = ParseEvent("pull-request")
+	assert.EqualError(t, err, `unknown event "pull-request"`)
+	assert.Equal(t, Event("pull-request"), event)
+
+	action, err := ParseAction("synchronized")
+	assert.NoError(t, err)
+	assert.Equal(t, ActionSynchronized, action)
+
+	_, err = ParseAction("synchronize")
+	assert.EqualError(t, err, `unknown action "synchronize"`)
+
+	status, err := ParseStatus("killed")
+	assert.NoError(t, err)
+	assert.True(t, status.IsFailing())
+
+	_, err = ParseStatus("passed")
+	assert.EqualError(t, err, `unknown status "passed"`)
+}
+
+// Test the build event predicates
+func TestBuildEvent(t *testing.T) {
+	build := Build{Event: EventTag}
+	assert.True(t, build.IsTag())
+	assert.False(t, build.IsPush())
+
+	build = Build{Event: EventRollback}
+	assert.True(t, build.IsRollback())
+	assert.True(t, build.IsDeployment())
+	assert.False(t, build.IsPromotion())
+
+	build = Build{Event: EventPullRequest}
+	assert.True(t, build.IsPullRequest())
+	assert.False(t, build.IsCron())
+	assert.False(t, build.IsCustom())
+}
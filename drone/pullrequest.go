@@ -0,0 +1,56 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+import "strings"
+
+// PullRequest represents the pull request being built.
+type PullRequest struct {
+	// Number of the pull request, zero if the build is not for a pull
+	// request.
+	Number int `json:"number" yaml:"number"`
+
+	// Title of the pull request.
+	Title string `json:"title" yaml:"title"`
+
+	// Link to the pull request.
+	Link string `json:"link" yaml:"link"`
+
+	// SourceRepo is the slug of the repository the changes come from.
+	SourceRepo string `json:"source_repo" yaml:"source_repo"`
+
+	// TargetRepo is the slug of the repository the changes are merged into.
+	TargetRepo string `json:"target_repo" yaml:"target_repo"`
+
+	// SourceBranch the changes come from.
+	SourceBranch string `json:"source_branch" yaml:"source_branch"`
+
+	// TargetBranch the changes are merged into.
+	TargetBranch string `json:"target_branch" yaml:"target_branch"`
+
+	// HeadSHA of the latest commit of the source branch.
+	HeadSHA string `json:"head_sha" yaml:"head_sha"`
+
+	// BaseSHA of the commit of the target branch the changes are based on.
+	BaseSHA string `json:"base_sha" yaml:"base_sha"`
+}
+
+func (pr PullRequest) String() string {
+	return pr.Title
+}
+
+// IsFromFork checks if the pull request comes from a different repository
+// than the one it targets.
+//
+// Plugins should be careful exposing secrets to pull requests from forks.
+// False is returned if either repository is unknown.
+func (pr PullRequest) IsFromFork() bool {
+	if pr.SourceRepo == "" || pr.TargetRepo == "" {
+		return false
+	}
+
+	return !strings.EqualFold(pr.SourceRepo, pr.TargetRepo)
+}
@@ -8,34 +8,34 @@ package drone
 // Repo represents the repository for the build.
 type Repo struct {
 	// Slug for the full name of a repo.
-	Slug string
+	Slug string `json:"slug" yaml:"slug"`
 
 	// SCM for the used SCM.
-	SCM string
+	SCM string `json:"scm" yaml:"scm"`
 
 	// Owner for the repo owner.
-	Owner string
+	Owner string `json:"owner" yaml:"owner"`
 
 	// Name for the repo name.
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// Link for the link to the repo.
-	Link string
+	Link string `json:"link" yaml:"link"`
 
 	// Branch for the default branch of the repo.
-	Branch string
+	Branch string `json:"branch" yaml:"branch"`
 
 	// HTTPURL for the clone URL via HTTP.
-	HTTPURL string
+	HTTPURL string `json:"http_url" yaml:"http_url"`
 
 	// SSHURL for the clone URL via SSH
-	SSHURL string
+	SSHURL string `json:"ssh_url" yaml:"ssh_url"`
 
 	// Visbility for the visbility of the repo.
-	Visibility string
+	Visibility string `json:"visibility" yaml:"visibility"`
 
 	// Private to show if the repo is private.
-	Private bool
+	Private bool `json:"private" yaml:"private"`
 }
 
 func (r Repo) String() string {
@@ -11,31 +11,31 @@ package drone
 // a semantic version then SemVer.Error will have the reason.
 type SemVer struct {
 	// Version is the full semantic version.
-	Version string
+	Version string `json:"version" yaml:"version"`
 
 	// Major version number.
-	Major string
+	Major string `json:"major" yaml:"major"`
 
 	// Minor version number.
-	Minor string
+	Minor string `json:"minor" yaml:"minor"`
 
 	// Patch version number.
-	Patch string
+	Patch string `json:"patch" yaml:"patch"`
 
 	// Prerelease version.
-	Prerelease string
+	Prerelease string `json:"prerelease" yaml:"prerelease"`
 
 	// Build version number.
 	//
 	// This is signified by a + at the end of the tag.
-	Build string
+	Build string `json:"build" yaml:"build"`
 
 	// Short version of the semantic version string where labels and
 	// metadata are truncated.
-	Short string
+	Short string `json:"short" yaml:"short"`
 
 	// Error is the semantic version parsing error if the tag was invalid.
-	Error string
+	Error string `json:"error" yaml:"error"`
 }
 
 func (s SemVer) String() string {
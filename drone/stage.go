@@ -13,52 +13,52 @@ type Stage struct {
 	//
 	// This value is sourced from the `kind` attribute in the yaml
 	// configuration file
-	Kind string
+	Kind string `json:"kind" yaml:"kind"`
 
 	// Type is the type of resource being executed.
-	Type string
+	Type string `json:"type" yaml:"type"`
 
 	// Name is the name for the current running build stage.
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// Number is the stage number for the current running build stage.
-	Number int
+	Number int `json:"number" yaml:"number"`
 
 	// Machine provides the name of the host machine on which the build
 	// stage is currently running.
-	Machine string
+	Machine string `json:"machine" yaml:"machine"`
 
 	// OS is the target operating system for the current build stage.
-	OS string
+	OS string `json:"os" yaml:"os"`
 
 	// Arch is the platform architecture of the current build stage.
-	Arch string
+	Arch string `json:"arch" yaml:"arch"`
 
 	// Variant is the target architecture variant for the current build
 	// stage.
-	Variant string
+	Variant string `json:"variant" yaml:"variant"`
 
 	// Version is OS version for the current build stage.
-	Version string
+	Version string `json:"version" yaml:"version"`
 
 	// Status is the status for the current running build stage.
 	//
 	// If all of the stage's steps are passing, the status defaults to
 	// success.
-	Status string
+	Status Status `json:"status" yaml:"status"`
 
 	// Started is the unix timestamp for when a build stage was started by
 	// the runner.
-	Started time.Time
+	Started time.Time `json:"started" yaml:"started"`
 
 	// Finished is the unix timestamp for when the pipeline is finished.
 	//
 	// A running pipleine cannot have a finish timestamp, therefore, the
 	// system aways sets this value to the current timestamp.
-	Finished time.Time
+	Finished time.Time `json:"finished" yaml:"finished"`
 
 	// DependsOn is a list of dependencies for the current build stage.
-	DependsOn []string
+	DependsOn []string `json:"depends_on" yaml:"depends_on"`
 }
 
 func (s Stage) String() string {
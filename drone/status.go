@@ -0,0 +1,84 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+import "fmt"
+
+// Status of a build or stage.
+type Status string
+
+const (
+	// StatusSkipped is a build or stage that was not run.
+	StatusSkipped Status = "skipped"
+
+	// StatusBlocked is a build or stage awaiting approval.
+	StatusBlocked Status = "blocked"
+
+	// StatusDeclined is a build or stage that was not approved.
+	StatusDeclined Status = "declined"
+
+	// StatusWaiting is a stage waiting on the stages it depends on.
+	StatusWaiting Status = "waiting_on_dependencies"
+
+	// StatusPending is a build or stage waiting to run.
+	StatusPending Status = "pending"
+
+	// StatusRunning is a build or stage that is running.
+	StatusRunning Status = "running"
+
+	// StatusSuccess is a build or stage that passed.
+	StatusSuccess Status = "success"
+
+	// StatusFailure is a build or stage that failed.
+	StatusFailure Status = "failure"
+
+	// StatusKilled is a build or stage that was cancelled.
+	StatusKilled Status = "killed"
+
+	// StatusError is a build or stage that could not run.
+	StatusError Status = "error"
+)
+
+// statuses are the known statuses.
+var statuses = []Status{
+	StatusSkipped,
+	StatusBlocked,
+	StatusDeclined,
+	StatusWaiting,
+	StatusPending,
+	StatusRunning,
+	StatusSuccess,
+	StatusFailure,
+	StatusKilled,
+	StatusError,
+}
+
+// ParseStatus parses the status.
+//
+// An error is returned along with the status if it is unknown. An empty
+// status is valid.
+func ParseStatus(s string) (Status, error) {
+	status := Status(s)
+	if status != "" && !contains(statuses, status) {
+		return status, fmt.Errorf("unknown status %q", s)
+	}
+
+	return status, nil
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsPassing checks if the status is a success.
+func (s Status) IsPassing() bool {
+	return s == StatusSuccess
+}
+
+// IsFailing checks if the status is a failure, error or cancellation.
+func (s Status) IsFailing() bool {
+	return s == StatusFailure || s == StatusError || s == StatusKilled
+}
@@ -8,10 +8,10 @@ package drone
 // Step represents the currently running step within the stage.
 type Step struct {
 	// Name for the name of the current step.
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// Number is the numeric value of the step.
-	Number int
+	Number int `json:"number" yaml:"number"`
 }
 
 func (s Step) String() string {
@@ -8,15 +8,23 @@ package drone
 // System represents the available system variables.
 type System struct {
 	// Proto for the system protocol.
-	Proto string
+	Proto string `json:"proto" yaml:"proto"`
 
 	// Host for the system host name.
-	Host string
+	Host string `json:"host" yaml:"host"`
 
 	// Version for the system version.
-	Version string
+	Version string `json:"version" yaml:"version"`
+
+	// Kind of the host CI.
+	Kind HostKind `json:"kind" yaml:"kind"`
 }
 
 func (s System) String() string {
 	return s.Host
 }
+
+// Capabilities returns the features the host CI supports.
+func (s System) Capabilities() Capabilities {
+	return s.Kind.Capabilities()
+}
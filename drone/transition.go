@@ -0,0 +1,54 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+// Transition between the status of the previous build and the current build.
+type Transition string
+
+const (
+	// TransitionUnknown is used when either status is missing or not final.
+	TransitionUnknown Transition = ""
+
+	// TransitionFixed is a passing build after a failing build.
+	TransitionFixed Transition = "fixed"
+
+	// TransitionBroken is a failing build after a passing build.
+	TransitionBroken Transition = "broken"
+
+	// TransitionStillFailing is a failing build after a failing build.
+	TransitionStillFailing Transition = "still_failing"
+
+	// TransitionStillPassing is a passing build after a passing build.
+	TransitionStillPassing Transition = "still_passing"
+)
+
+func (t Transition) String() string {
+	return string(t)
+}
+
+// StatusTransition returns the transition from the previous status to the
+// current status.
+func StatusTransition(previous, current Status) Transition {
+	if !isFinal(previous) || !isFinal(current) {
+		return TransitionUnknown
+	}
+
+	switch {
+	case previous.IsFailing() && current.IsPassing():
+		return TransitionFixed
+	case previous.IsPassing() && current.IsFailing():
+		return TransitionBroken
+	case current.IsFailing():
+		return TransitionStillFailing
+	}
+
+	return TransitionStillPassing
+}
+
+// isFinal checks if the status either passed or failed.
+func isFinal(status Status) bool {
+	return status.IsPassing() || status.IsFailing()
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package drone
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
+)
+
+// ErrOutsideWorkspace is returned when a path resolves outside of the
+// workspace.
+var ErrOutsideWorkspace = errors.New("path is outside of the workspace")
+
+// Workspace represents the directory the repository is cloned into.
+type Workspace struct {
+	// Dir is the absolute path of the workspace.
+	Dir string `json:"dir" yaml:"dir"`
+
+	// Base is the base directory of the workspace.
+	Base string `json:"base" yaml:"base"`
+
+	// Path of the workspace relative to the base directory.
+	Path string `json:"path" yaml:"path"`
+}
+
+func (w Workspace) String() string {
+	return w.Root()
+}
+
+// Root returns the absolute path of the workspace.
+//
+// This is Dir if set, otherwise Path joined to Base, falling back to the
+// working directory when running outside of a pipeline.
+func (w Workspace) Root() string {
+	root := w.Dir
+
+	if root == "" && w.Base != "" {
+		root = filepath.Join(w.Base, w.Path)
+	}
+
+	if root == "" {
+		root = "."
+	}
+
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+
+	return root
+}
+
+// Resolve resolves the user supplied path against the workspace.
+//
+// Relative paths are joined to the root of the workspace. An error wrapping
+// ErrOutsideWorkspace is returned if the path escapes the workspace, either
+// through ../ elements, an absolute path or a symlink.
+func (w Workspace) Resolve(path string) (string, error) {
+	root := w.Root()
+	resolved := w.join(root, path)
+
+	if !within(root, resolved) {
+		return "", fmt.Errorf("%w: %s", ErrOutsideWorkspace, path)
+	}
+
+	if err := confine(root, resolved); err != nil {
+		return "", fmt.Errorf("%w: %s %v", ErrOutsideWorkspace, path, err)
+	}
+
+	return resolved, nil
+}
+
+// ResolveUnconfined resolves the user supplied path against the workspace
+// without confining it to the workspace.
+//
+// This should only be used when the plugin explicitly allows paths outside
+// of the workspace.
+func (w Workspace) ResolveUnconfined(path string) string {
+	return w.join(w.Root(), path)
+}
+
+// ScratchDir creates a temporary directory outside of the workspace which is
+// removed when the plugin exits.
+func (w Workspace) ScratchDir(pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+
+	cleanup.Register(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	return dir, nil
+}
+
+// join joins the path to the root unless it is absolute.
+func (w Workspace) join(root, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(root, path)
+}
+
+// within checks if path is root or within root.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// maxLinks is the number of symlinks followed before giving up.
+const maxLinks = 255
+
+// confine walks the path within root one element at a time, following each
+// symlink and checking its target stays within root.
+//
+// Targets are checked whether they exist or not, so a dangling symlink cannot
+// be used to create a file outside of root.
+func confine(root, path string) error {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		realRoot = root
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return err
+	}
+
+	parts := split(rel)
+	current := realRoot
+	links := 0
+
+	for len(parts) != 0 {
+		part := parts[0]
+		parts = parts[1:]
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			current = filepath.Dir(current)
+			if !within(realRoot, current) {
+				return errors.New("escapes through ..")
+			}
+
+			continue
+		}
+
+		next := filepath.Join(current, part)
+
+		info, err := os.Lstat(next)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+
+			current = next
+			continue
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			current = next
+			continue
+		}
+
+		links++
+		if links > maxLinks {
+			return errors.New("has too many links")
+		}
+
+		target, err := os.Readlink(next)
+		if err != nil {
+			return err
+		}
+
+		if filepath.IsAbs(target) {
+			switch {
+			case within(realRoot, target):
+				rel, _ = filepath.Rel(realRoot, target)
+			case within(root, target):
+				rel, _ = filepath.Rel(root, target)
+			default:
+				return fmt.Errorf("links to %s", target)
+			}
+
+			current, target = realRoot, rel
+		}
+
+		parts = append(split(target), parts...)
+	}
+
+	return nil
+}
+
+// split splits the path into its elements.
+func split(path string) []string {
+	return strings.Split(filepath.ToSlash(path), "/")
+}
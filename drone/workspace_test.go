@@ -0,0 +1,58 @@
+package drone
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test resolving paths confined to the workspace
+func TestWorkspaceResolve(t *testing.T) {
+	base := t.TempDir()
+	outside := t.TempDir()
+
+	ws := Workspace{Base: base, Path: "src"}
+	root := filepath.Join(base, "src")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "dist"), 0755))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+	assert.NoError(t, os.Symlink(filepath.Join(root, "dist"), filepath.Join(root, "inside")))
+	assert.NoError(t, os.Symlink(filepath.Join(outside, "missing"), filepath.Join(root, "dangling")))
+	assert.NoError(t, os.Symlink("../../"+filepath.Base(outside)+"/missing", filepath.Join(root, "relative")))
+	assert.NoError(t, os.Symlink("dist/new.tar", filepath.Join(root, "new")))
+
+	for path, expected := range map[string]string{
+		"dist":                         filepath.Join(root, "dist"),
+		"./dist/../dist/app.tar":       filepath.Join(root, "dist", "app.tar"),
+		"inside/app.tar":               filepath.Join(root, "inside", "app.tar"),
+		filepath.Join(root, "missing"): filepath.Join(root, "missing"),
+		"new":                          filepath.Join(root, "new"),
+	} {
+		resolved, err := ws.Resolve(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, resolved, path)
+	}
+
+	for _, path := range []string{"../../etc/passwd", "/etc/passwd", "escape/secret", "escape", "dangling", "relative", "dist/../relative"} {
+		_, err := ws.Resolve(path)
+		assert.True(t, errors.Is(err, ErrOutsideWorkspace), path)
+	}
+
+	assert.Equal(t, "/etc/passwd", ws.ResolveUnconfined("/etc/passwd"))
+}
+
+// Test scratch directories are removed on cleanup
+func TestWorkspaceScratchDir(t *testing.T) {
+	dir, err := Workspace{}.ScratchDir("scratch-")
+	assert.NoError(t, err)
+	assert.DirExists(t, dir)
+
+	cleanup.Run()
+
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,165 @@
+package dronetest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/errors"
+	"github.com/drone-plugins/drone-plugin-lib/harness"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/drone-plugins/drone-plugin-lib/urfave"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// testPlugin publishes the tag of the pipeline.
+type testPlugin struct {
+	pipeline drone.Pipeline
+}
+
+func (p *testPlugin) Validate() error {
+	if os.Getenv("PLUGIN_TOKEN") == "" {
+		return errors.ExitMessage("missing token")
+	}
+
+	return nil
+}
+
+func (p *testPlugin) Execute() error {
+	logrus.WithField("tag", p.pipeline.Build.Tag).Info("publishing")
+
+	if err := harness.SetOutput("VERSION", p.pipeline.SemVer.Short); err != nil {
+		return err
+	}
+
+	if err := harness.SetSecret("TOKEN", os.Getenv("PLUGIN_TOKEN")); err != nil {
+		return err
+	}
+
+	return drone.WritePluginArtifactFile(
+		drone.Docker,
+		os.Getenv(ArtifactFile),
+		"https://index.docker.io/",
+		"octocat/hello-world",
+		"sha256:1234",
+		[]string{p.pipeline.SemVer.Short},
+	)
+}
+
+// Test running a drone.Plugin within a tag scenario
+func TestRunPlugin(t *testing.T) {
+	scenario := New().
+		Tag("v1.2.3").
+		Setting("token", "hunter2")
+
+	result := scenario.RunPlugin(t, &testPlugin{pipeline: scenario.Pipeline()})
+
+	assert.Equal(t, 0, result.ExitCode)
+	assert.NoError(t, result.Err)
+	assert.Equal(t, []string{"publishing"}, result.Messages())
+	assert.Equal(t, "v1.2.3", result.Logs[0].Fields["tag"])
+	assert.Equal(t, "1.2.3", result.Outputs["VERSION"])
+	assert.Equal(t, "hunter2", result.Secrets["TOKEN"])
+
+	if assert.NotNil(t, result.Artifact) {
+		assert.Equal(t, "octocat/hello-world:1.2.3", result.Artifact.Data.Images[0].Image)
+	}
+}
+
+// Test a failed validation is reported with its exit code
+func TestRunPluginError(t *testing.T) {
+	result := New().RunPlugin(t, &testPlugin{})
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.EqualError(t, result.Err, "missing token")
+	assert.Empty(t, result.Outputs)
+}
+
+// Test running the main function of a urfave plugin
+func TestRunMain(t *testing.T) {
+	main := func() {
+		app := &cli.App{
+			Name:  "plugin",
+			Flags: urfave.Flags(),
+			Action: func(ctx *cli.Context) error {
+				urfave.LoggingFromContext(ctx)
+				pipeline := urfave.PipelineFromContext(ctx)
+
+				if pipeline.Build.Event != "pull_request" {
+					return nil
+				}
+
+				if err := harness.SetErrorMetadata("pull requests are not supported", "E001", "user"); err != nil {
+					return err
+				}
+
+				return errors.ExitMessagef("pull request %d is not supported", pipeline.Build.PullRequest)
+			},
+		}
+
+		errors.HandleExit(app.Run(os.Args))
+	}
+
+	result := New().RunMain(t, main)
+	assert.Equal(t, 0, result.ExitCode)
+
+	result = New().PullRequest(42, "feature", "main").RunMain(t, main)
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Messages(), "pull request 42 is not supported")
+	assert.Equal(t, "E001", result.ErrorMetadata[harness.ErrorCodeKey])
+	assert.Equal(t, "octocat/hello-world", result.Logs[0].Fields["repo"])
+}
+
+// Test capturing the loggers created from the context and restoring secrets
+func TestRunMainContext(t *testing.T) {
+	main := func(ctx context.Context) {
+		app := &cli.App{
+			Name: "plugin",
+			Flags: append([]cli.Flag{
+				urfave.Secret(&cli.StringFlag{
+					Name:    "token",
+					EnvVars: []string{"PLUGIN_TOKEN"},
+				}),
+			}, urfave.Flags()...),
+			Action: func(ctx *cli.Context) error {
+				urfave.LoggerFromContext(ctx).WithField("token", ctx.String("token")).Info("logrus")
+				urfave.SlogFromContext(ctx).Info("slog", "token", ctx.String("token"))
+
+				return nil
+			},
+		}
+
+		errors.HandleExit(app.RunContext(ctx, os.Args))
+	}
+
+	result := New().Setting("token", "hunter2").RunMainContext(t, main)
+
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Equal(t, []string{"logrus", "slog"}, result.Messages())
+
+	for _, entry := range result.Logs {
+		assert.Equal(t, "***", entry.Fields["token"])
+		assert.Equal(t, "octocat/hello-world", entry.Fields["repo"])
+	}
+
+	assert.Equal(t, "hunter2", secret.Mask("hunter2"))
+}
+
+// Test settings of the host are not passed to the scenario
+func TestRunPluginClearsSettings(t *testing.T) {
+	t.Setenv("PLUGIN_TOKEN", "host")
+	t.Setenv("INPUT_TOKEN", "host")
+	t.Setenv("RUNNER_OS", "Linux")
+
+	result := New().RunPlugin(t, &testPlugin{})
+	assert.Equal(t, 1, result.ExitCode)
+	assert.EqualError(t, result.Err, "missing token")
+
+	for _, env := range []string{"PLUGIN_TOKEN", "INPUT_TOKEN", "RUNNER_OS"} {
+		_, ok := os.LookupEnv(env)
+		assert.False(t, ok, env)
+	}
+}
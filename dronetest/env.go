@@ -0,0 +1,74 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package dronetest
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+var (
+	// hostEnv are the environment variables used to detect the host CI.
+	hostEnv = []string{
+		"CI",
+		"DRONE",
+		"GITHUB_ACTIONS",
+		"GITLAB_CI",
+	}
+
+	// hostEnvPrefixes are the prefixes of the environment variables set by
+	// the host CI, along with the plugin settings and GitHub Actions inputs
+	// so only the settings of the scenario are used.
+	hostEnvPrefixes = []string{
+		"CI_",
+		"DRONE_",
+		"GITHUB_",
+		"GITLAB_",
+		"HARNESS_",
+		"RUNNER_",
+		"PLUGIN_",
+		"INPUT_",
+	}
+)
+
+// ClearEnv unsets the environment variables of the host CI, and any plugin
+// settings, for the duration of the test.
+//
+// Tests reading the pipeline otherwise depend on where they run, as the CI
+// running them sets variables like DRONE and DRONE_SYSTEM_VERSION, and the
+// shell of the developer can set PLUGIN_ or INPUT_ variables.
+func ClearEnv(t testing.TB) {
+	t.Helper()
+
+	for _, kv := range os.Environ() {
+		k, _, _ := strings.Cut(kv, "=")
+		if !isHostEnv(k) {
+			continue
+		}
+
+		// Setenv restores the value once the test completes.
+		t.Setenv(k, "")
+		os.Unsetenv(k)
+	}
+}
+
+// isHostEnv checks if the environment variable is set by the host CI.
+func isHostEnv(k string) bool {
+	for _, env := range hostEnv {
+		if k == env {
+			return true
+		}
+	}
+
+	for _, prefix := range hostEnvPrefixes {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,434 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package dronetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/errors"
+	"github.com/drone-plugins/drone-plugin-lib/harness"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	v3 "github.com/harness/godotenv/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// ArtifactFile is the setting used for the path of the docker artifact file.
+const ArtifactFile = "PLUGIN_ARTIFACT_FILE"
+
+type (
+	// Result captures everything a plugin reported back to the pipeline.
+	Result struct {
+		// ExitCode the plugin exited with.
+		ExitCode int
+
+		// Err returned by the plugin.
+		//
+		// This is only set when running a drone.Plugin.
+		Err error
+
+		// Logs written by the plugin through logrus or slog.
+		//
+		// This includes the logrus and slog loggers carried by the context
+		// passed to RunMainContext.
+		Logs []LogEntry
+
+		// Outputs written to the DRONE_OUTPUT file.
+		Outputs map[string]string
+
+		// Secrets written to the HARNESS_OUTPUT_SECRET_FILE file.
+		Secrets map[string]string
+
+		// ErrorMetadata written to the ERROR_METADATA_FILE file.
+		ErrorMetadata map[string]string
+
+		// Artifact written to the PLUGIN_ARTIFACT_FILE file.
+		//
+		// This is nil if no artifact was written.
+		Artifact *drone.DockerArtifact
+	}
+
+	// LogEntry is a captured log entry.
+	LogEntry struct {
+		// Level of the entry, using the logrus level names.
+		Level string
+
+		// Message of the entry.
+		Message string
+
+		// Fields of the entry.
+		Fields map[string]interface{}
+	}
+)
+
+// Messages returns the message of every captured log entry.
+func (r *Result) Messages() []string {
+	messages := make([]string, 0, len(r.Logs))
+	for _, entry := range r.Logs {
+		messages = append(messages, entry.Message)
+	}
+
+	return messages
+}
+
+// exitSignal is the panic value used to unwind a plugin that exited.
+type exitSignal struct {
+	code int
+}
+
+// RunPlugin runs Validate and Execute of the plugin within the scenario.
+//
+// Execute is skipped if Validate fails.
+func (s *Scenario) RunPlugin(t testing.TB, plugin drone.Plugin) *Result {
+	t.Helper()
+
+	return s.run(t, func(context.Context) error {
+		if err := plugin.Validate(); err != nil {
+			return err
+		}
+
+		return plugin.Execute()
+	})
+}
+
+// RunMain runs the main function of a plugin within the scenario.
+//
+// The exit code is captured from errors.HandleExit, urfave/cli and logrus
+// fatal logging. Calls to os.Exit cannot be captured and terminate the test.
+func (s *Scenario) RunMain(t testing.TB, main func()) *Result {
+	t.Helper()
+
+	return s.RunMainContext(t, func(context.Context) {
+		main()
+	})
+}
+
+// RunMainContext runs the main function of a plugin within the scenario,
+// passing it a context carrying the loggers used to capture the logs.
+//
+// Pass the context to cli.App RunContext so urfave.LoggerFromContext and
+// urfave.SlogFromContext log through the captured loggers.
+func (s *Scenario) RunMainContext(t testing.TB, main func(ctx context.Context)) *Result {
+	t.Helper()
+
+	return s.run(t, func(ctx context.Context) error {
+		main(ctx)
+		return nil
+	})
+}
+
+// run sets up the environment, runs fn and captures the results.
+//
+// Secrets registered while running are removed again once it finishes.
+func (s *Scenario) run(t testing.TB, fn func(ctx context.Context) error) *Result {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		harness.DroneOutputFile:         filepath.Join(dir, "output.env"),
+		harness.HarnessOutputSecretFile: filepath.Join(dir, "secret.env"),
+		harness.MetadataFile:            filepath.Join(dir, "error.env"),
+		ArtifactFile:                    filepath.Join(dir, "artifact.json"),
+	}
+
+	ClearEnv(t)
+
+	env := s.Environ()
+	for k, v := range files {
+		if _, ok := env[k]; !ok {
+			env[k] = v
+		}
+	}
+
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	args := os.Args
+	os.Args = []string{"plugin"}
+	defer func() { os.Args = args }()
+
+	restoreSecrets := secret.Snapshot()
+	defer restoreSecrets()
+
+	rec := &recorder{}
+	ctx, restore := capture(rec)
+	defer restore()
+
+	result := &Result{}
+	result.ExitCode, result.Err = invoke(func() error {
+		return fn(ctx)
+	})
+
+	cleanup.Run()
+	restore()
+
+	result.Logs = rec.logs()
+	result.Outputs = readEnvFile(t, env[harness.DroneOutputFile])
+	result.Secrets = readEnvFile(t, env[harness.HarnessOutputSecretFile])
+	result.ErrorMetadata = readEnvFile(t, env[harness.MetadataFile])
+	result.Artifact = readArtifact(t, env[ArtifactFile])
+
+	return result
+}
+
+// invoke runs fn, translating exits into an exit code.
+func invoke(fn func() error) (code int, err error) {
+	exiter := errors.OsExiter
+	cliExiter := cli.OsExiter
+	logrusExit := logrus.StandardLogger().ExitFunc
+
+	errors.OsExiter = exit
+	cli.OsExiter = exit
+	logrus.StandardLogger().ExitFunc = exit
+
+	defer func() {
+		errors.OsExiter = exiter
+		cli.OsExiter = cliExiter
+		logrus.StandardLogger().ExitFunc = logrusExit
+
+		if r := recover(); r != nil {
+			signal, ok := r.(exitSignal)
+			if !ok {
+				panic(r)
+			}
+
+			code = signal.code
+		}
+	}()
+
+	err = fn()
+
+	if err != nil {
+		code = 1
+
+		if e, ok := err.(errors.ExitCoder); ok {
+			code = e.Code()
+		}
+	}
+
+	return code, err
+}
+
+// exit unwinds the plugin with the exit code.
+func exit(code int) {
+	panic(exitSignal{code: code})
+}
+
+// capture records all logrus and slog output, returning a context carrying
+// loggers writing to the recorder and a function to restore the previous
+// state.
+func capture(rec *recorder) (context.Context, func()) {
+	std := logrus.StandardLogger()
+
+	level := std.GetLevel()
+	formatter := std.Formatter
+	out := std.Out
+	hooks := std.ReplaceHooks(logrus.LevelHooks{})
+	def := slog.Default()
+
+	std.SetOutput(&bytes.Buffer{})
+	std.AddHook(rec)
+	slog.SetDefault(slog.New(rec))
+
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+	logger.AddHook(rec)
+	logger.ExitFunc = exit
+
+	ctx := drone.WithLogger(context.Background(), logger)
+	ctx = drone.WithSlog(ctx, slog.New(rec))
+
+	var once sync.Once
+
+	return ctx, func() {
+		once.Do(func() {
+			std.SetLevel(level)
+			std.SetFormatter(formatter)
+			std.SetOutput(out)
+			std.ReplaceHooks(hooks)
+			slog.SetDefault(def)
+		})
+	}
+}
+
+// readEnvFile reads the key value file if it exists.
+func readEnvFile(t testing.TB, path string) map[string]string {
+	t.Helper()
+
+	data := map[string]string{}
+
+	if _, err := os.Stat(path); err != nil {
+		return data
+	}
+
+	values, err := v3.Read(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %s", path, err)
+	}
+
+	for k, v := range values {
+		data[k] = v
+	}
+
+	return data
+}
+
+// readArtifact reads the docker artifact file if it exists.
+func readArtifact(t testing.TB, path string) *drone.DockerArtifact {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	artifact := &drone.DockerArtifact{}
+	if err := json.Unmarshal(b, artifact); err != nil {
+		t.Fatalf("failed to parse artifact %s: %s", path, err)
+	}
+
+	return artifact
+}
+
+// recorder captures logrus entries and slog records.
+type recorder struct {
+	mu      sync.Mutex
+	attrs   []slog.Attr
+	entries []func() LogEntry
+	parent  *recorder
+}
+
+// Levels implements the logrus.Hook interface.
+func (r *recorder) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+//
+// The entry is converted once the plugin finishes so fields and masking
+// applied by hooks fired after the recorder are captured.
+func (r *recorder) Fire(entry *logrus.Entry) error {
+	r.add(func() LogEntry {
+		fields := make(map[string]interface{}, len(entry.Data))
+		for k, v := range entry.Data {
+			fields[k] = v
+		}
+
+		return LogEntry{
+			Level:   entry.Level.String(),
+			Message: entry.Message,
+			Fields:  fields,
+		}
+	})
+
+	return nil
+}
+
+// Enabled implements the slog.Handler interface.
+func (r *recorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements the slog.Handler interface.
+func (r *recorder) Handle(_ context.Context, record slog.Record) error {
+	fields := map[string]interface{}{}
+	for _, a := range r.attrs {
+		fields[a.Key] = a.Value.Resolve().Any()
+	}
+
+	record.Attrs(func(a slog.Attr) bool {
+		fields[a.Key] = a.Value.Resolve().Any()
+		return true
+	})
+
+	entry := LogEntry{
+		Level:   slogLevel(record.Level),
+		Message: record.Message,
+		Fields:  fields,
+	}
+
+	r.add(func() LogEntry {
+		return entry
+	})
+
+	return nil
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (r *recorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recorder{
+		attrs:  append(append([]slog.Attr{}, r.attrs...), attrs...),
+		parent: r.root(),
+	}
+}
+
+// WithGroup implements the slog.Handler interface.
+//
+// Groups are flattened into the captured fields.
+func (r *recorder) WithGroup(string) slog.Handler {
+	return r
+}
+
+// root returns the recorder holding the captured entries.
+func (r *recorder) root() *recorder {
+	if r.parent != nil {
+		return r.parent
+	}
+
+	return r
+}
+
+// add appends the entry to the captured entries.
+func (r *recorder) add(entry func() LogEntry) {
+	root := r.root()
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	root.entries = append(root.entries, entry)
+}
+
+// logs returns the captured entries.
+func (r *recorder) logs() []LogEntry {
+	root := r.root()
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	logs := make([]LogEntry, 0, len(root.entries))
+	for _, entry := range root.entries {
+		logs = append(logs, entry())
+	}
+
+	return logs
+}
+
+// slogLevel converts the slog level to the logrus level name.
+func slogLevel(level slog.Level) string {
+	switch {
+	case level < slog.LevelDebug:
+		return logrus.TraceLevel.String()
+	case level < slog.LevelInfo:
+		return logrus.DebugLevel.String()
+	case level < slog.LevelWarn:
+		return logrus.InfoLevel.String()
+	case level < slog.LevelError:
+		return logrus.WarnLevel.String()
+	default:
+		return logrus.ErrorLevel.String()
+	}
+}
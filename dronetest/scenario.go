@@ -0,0 +1,274 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package dronetest provides helpers for testing plugins built on this
+// library.
+//
+// A Scenario describes the pipeline a plugin runs in. It renders the
+// matching environment variables and runs the plugin in-process, capturing
+// everything the plugin reports back to the pipeline.
+//
+//	func TestPublish(t *testing.T) {
+//		result := dronetest.New().
+//			Tag("v1.2.3").
+//			Setting("repo", "octocat/hello-world").
+//			RunPlugin(t, &Plugin{})
+//
+//		assert.Equal(t, 0, result.ExitCode)
+//		assert.Equal(t, "1.2.3", result.Outputs["VERSION"])
+//	}
+//
+// Scenarios set environment variables with testing.T.Setenv so they cannot
+// be used in parallel tests.
+package dronetest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// semVerRegexp matches a semantic version with an optional v prefix.
+var semVerRegexp = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// Scenario is a fluent builder for the pipeline a plugin runs in.
+type Scenario struct {
+	pipeline drone.Pipeline
+	settings map[string]string
+	env      map[string]string
+}
+
+// New creates a Scenario for a push to the main branch of a repository.
+func New() *Scenario {
+	started := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	s := &Scenario{
+		pipeline: drone.Pipeline{
+			Build: drone.Build{
+				Number:  1,
+				Status:  drone.StatusSuccess,
+				Link:    "https://drone.example.com/octocat/hello-world/1",
+				Created: started,
+				Started: started,
+			},
+			Repo: drone.Repo{
+				Slug:       "octocat/hello-world",
+				SCM:        "git",
+				Owner:      "octocat",
+				Name:       "hello-world",
+				Link:       "https://github.com/octocat/hello-world",
+				Branch:     "main",
+				HTTPURL:    "https://github.com/octocat/hello-world.git",
+				SSHURL:     "git@github.com:octocat/hello-world.git",
+				Visibility: "public",
+			},
+			Commit: drone.Commit{
+				SHA:     "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+				Before:  "762941318ee16e59dabbacb1b4049eec22f0d303",
+				After:   "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+				Link:    "https://github.com/octocat/hello-world/commit/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+				Message: drone.ParseMessage("Update README"),
+				Author: drone.Author{
+					Username: "octocat",
+					Name:     "The Octocat",
+					Email:    "octocat@github.com",
+					Avatar:   "https://avatars.githubusercontent.com/u/583231",
+				},
+			},
+			Stage: drone.Stage{
+				Kind:    "pipeline",
+				Type:    "docker",
+				Name:    "default",
+				Number:  1,
+				Machine: "runner",
+				OS:      "linux",
+				Arch:    "amd64",
+				Status:  drone.StatusSuccess,
+				Started: started,
+			},
+			Step: drone.Step{
+				Name:   "plugin",
+				Number: 1,
+			},
+			System: drone.System{
+				Proto:   "https",
+				Host:    "drone.example.com",
+				Version: "2.0.0",
+				Kind:    drone.HostDrone,
+			},
+		},
+		settings: map[string]string{},
+		env:      map[string]string{},
+	}
+
+	return s.Push("main")
+}
+
+// Push sets the scenario to a push event for the branch.
+func (s *Scenario) Push(branch string) *Scenario {
+	s.resetEvent(drone.EventPush)
+
+	s.pipeline.Build.Branch = branch
+	s.pipeline.Build.TargetBranch = branch
+	s.pipeline.Commit.Branch = branch
+	s.pipeline.Commit.Ref = "refs/heads/" + branch
+
+	return s
+}
+
+// Tag sets the scenario to a tag event for the tag.
+//
+// If the tag is a semantic version the SemVer of the pipeline is populated.
+func (s *Scenario) Tag(tag string) *Scenario {
+	s.resetEvent(drone.EventTag)
+
+	s.pipeline.Build.Tag = tag
+	s.pipeline.Commit.Ref = "refs/tags/" + tag
+	s.pipeline.SemVer = parseSemVer(tag)
+
+	return s
+}
+
+// PullRequest sets the scenario to a pull request event merging the source
+// branch into the target branch.
+func (s *Scenario) PullRequest(number int, source, target string) *Scenario {
+	s.resetEvent(drone.EventPullRequest)
+
+	s.pipeline.Build.Action = drone.ActionOpened
+	s.pipeline.Build.PullRequest = number
+	s.pipeline.Build.Branch = target
+	s.pipeline.Build.SourceBranch = source
+	s.pipeline.Build.TargetBranch = target
+	s.pipeline.Commit.Branch = target
+	s.pipeline.Commit.Ref = fmt.Sprintf("refs/pull/%d/head", number)
+
+	return s
+}
+
+// Promotion sets the scenario to a promotion of the build to the target
+// environment.
+func (s *Scenario) Promotion(target string) *Scenario {
+	branch := s.pipeline.Build.Branch
+
+	s.resetEvent(drone.EventPromote)
+
+	s.pipeline.Build.Branch = branch
+	s.pipeline.Build.TargetBranch = branch
+	s.pipeline.Build.Parent = s.pipeline.Build.Number
+	s.pipeline.Build.Number++
+	s.pipeline.Build.DeployTo = target
+	s.pipeline.Build.DeployID = 1
+	s.pipeline.Commit.Branch = branch
+	s.pipeline.Commit.Ref = "refs/heads/" + branch
+
+	return s
+}
+
+// Cron sets the scenario to a cron event for the branch.
+func (s *Scenario) Cron(branch string) *Scenario {
+	s.Push(branch)
+	s.pipeline.Build.Event = drone.EventCron
+
+	return s
+}
+
+// Status sets the status of the build and stage.
+func (s *Scenario) Status(status drone.Status) *Scenario {
+	s.pipeline.Build.Status = status
+	s.pipeline.Stage.Status = status
+
+	return s
+}
+
+// Setting sets a plugin setting, which is rendered as a PLUGIN_ variable.
+//
+// The name is converted the same way Drone converts the keys of the settings
+// block, so `api_key` becomes `PLUGIN_API_KEY`.
+func (s *Scenario) Setting(name, value string) *Scenario {
+	s.settings[name] = value
+
+	return s
+}
+
+// Env sets an additional environment variable.
+func (s *Scenario) Env(key, value string) *Scenario {
+	s.env[key] = value
+
+	return s
+}
+
+// With calls fn to modify the pipeline directly.
+func (s *Scenario) With(fn func(*drone.Pipeline)) *Scenario {
+	fn(&s.pipeline)
+
+	return s
+}
+
+// Pipeline returns the pipeline of the scenario.
+func (s *Scenario) Pipeline() drone.Pipeline {
+	return s.pipeline
+}
+
+// Environ returns the environment variables for the scenario.
+func (s *Scenario) Environ() map[string]string {
+	env := s.pipeline.EnvironMap()
+
+	for k, v := range s.settings {
+		env[settingKey(k)] = v
+	}
+
+	for k, v := range s.env {
+		env[k] = v
+	}
+
+	return env
+}
+
+// resetEvent clears the event specific values and sets the event.
+func (s *Scenario) resetEvent(event drone.Event) {
+	s.pipeline.Build.Event = event
+	s.pipeline.Build.Action = ""
+	s.pipeline.Build.Branch = ""
+	s.pipeline.Build.Tag = ""
+	s.pipeline.Build.PullRequest = 0
+	s.pipeline.Build.SourceBranch = ""
+	s.pipeline.Build.TargetBranch = ""
+	s.pipeline.Build.DeployTo = ""
+	s.pipeline.Build.DeployID = 0
+	s.pipeline.Commit.Branch = ""
+	s.pipeline.Commit.Ref = ""
+	s.pipeline.SemVer = drone.SemVer{}
+}
+
+// settingKey converts the setting name into its environment variable.
+func settingKey(name string) string {
+	name = strings.ToUpper(name)
+	name = strings.NewReplacer("-", "_", ".", "_").Replace(name)
+
+	return "PLUGIN_" + name
+}
+
+// parseSemVer parses the tag into a drone.SemVer.
+func parseSemVer(tag string) drone.SemVer {
+	m := semVerRegexp.FindStringSubmatch(tag)
+	if m == nil {
+		return drone.SemVer{
+			Error: fmt.Sprintf("%s is not a valid semver", tag),
+		}
+	}
+
+	return drone.SemVer{
+		Version:    strings.TrimPrefix(tag, "v"),
+		Major:      m[1],
+		Minor:      m[2],
+		Patch:      m[3],
+		Prerelease: m[4],
+		Build:      m[5],
+		Short:      fmt.Sprintf("%s.%s.%s", m[1], m[2], m[3]),
+	}
+}
@@ -1,12 +1,20 @@
 package errors
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"sort"
 
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
 	"github.com/sirupsen/logrus"
 )
 
+// OsExiter is the function used to exit the process after handling an
+// ExitCoder. It can be replaced to capture the exit code in tests.
+var OsExiter = os.Exit
+
 // ExitCoder defines the interface for exit code handling.
 type ExitCoder interface {
 	error
@@ -14,11 +22,20 @@ type ExitCoder interface {
 	Fields() logrus.Fields
 }
 
+// SlogExitCoder defines the interface for exit code handling with slog
+// attributes.
+type SlogExitCoder interface {
+	error
+	Code() int
+	Attrs() []slog.Attr
+}
+
 // ExitError simply implements the defined interface.
 type ExitError struct {
 	message interface{}
 	code    int
 	fields  logrus.Fields
+	attrs   []slog.Attr
 }
 
 // Error implements the ExitCoder interface.
@@ -32,8 +49,44 @@ func (e ExitError) Code() int {
 }
 
 // Fields implements the ExitCoder interface.
+//
+// If the error was created with slog attributes they are converted into
+// fields.
 func (e ExitError) Fields() logrus.Fields {
-	return e.fields
+	if e.fields != nil || e.attrs == nil {
+		return e.fields
+	}
+
+	fields := logrus.Fields{}
+	for _, a := range e.attrs {
+		fields[a.Key] = a.Value.Resolve().Any()
+	}
+
+	return fields
+}
+
+// Attrs implements the SlogExitCoder interface.
+//
+// If the error was created with logrus fields they are converted into
+// attributes sorted by key.
+func (e ExitError) Attrs() []slog.Attr {
+	if e.attrs != nil || e.fields == nil {
+		return e.attrs
+	}
+
+	keys := make([]string, 0, len(e.fields))
+	for k := range e.fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, e.fields[k]))
+	}
+
+	return attrs
 }
 
 // ExitMessage initializes a new ExitCoder implementation.
@@ -61,21 +114,63 @@ func WithFields(message interface{}, fields logrus.Fields) ExitError {
 	}
 }
 
+// WithAttrs initializes a new ExitCoder implementation with slog attributes.
+func WithAttrs(message interface{}, attrs ...slog.Attr) ExitError {
+	return ExitError{
+		message: message,
+		code:    1,
+		attrs:   attrs,
+	}
+}
+
 // HandleExit ist used within the main handler to exit properly.
 func HandleExit(err error) {
+	HandleExitWithLogger(err, logrus.StandardLogger())
+}
+
+// HandleExitWithLogger is used within the main handler to exit properly
+// while logging the error to the provided logger. Any registered cleanup
+// functions are run first.
+func HandleExitWithLogger(err error, logger *logrus.Logger) {
+	cleanup.Run()
+
 	if err == nil {
 		return
 	}
 
 	if e, ok := err.(ExitCoder); ok {
 		if e.Error() != "" {
-			logrus.WithFields(
+			logger.WithFields(
 				e.Fields(),
 			).Error(
 				e.Error(),
 			)
 		}
 
-		os.Exit(e.Code())
+		OsExiter(e.Code())
+	}
+}
+
+// HandleExitWithSlog is used within the main handler to exit properly while
+// logging the error to the provided slog logger. Any registered cleanup
+// functions are run first.
+func HandleExitWithSlog(err error, logger *slog.Logger) {
+	cleanup.Run()
+
+	if err == nil {
+		return
+	}
+
+	if e, ok := err.(SlogExitCoder); ok {
+		if e.Error() != "" {
+			logger.LogAttrs(
+				context.Background(),
+				slog.LevelError,
+				e.Error(),
+				e.Attrs()...,
+			)
+		}
+
+		OsExiter(e.Code())
 	}
 }
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test converting logrus fields into slog attributes
+func TestExitErrorAttrs(t *testing.T) {
+	err := WithFields("failed", logrus.Fields{"repo": "octocat/hello-world", "build": 42})
+
+	assert.Equal(t, logrus.Fields{"repo": "octocat/hello-world", "build": 42}, err.Fields())
+	assert.Equal(t, []slog.Attr{
+		slog.Any("build", 42),
+		slog.Any("repo", "octocat/hello-world"),
+	}, err.Attrs())
+
+	assert.Nil(t, ExitMessage("failed").Attrs())
+}
+
+// Test converting slog attributes into logrus fields
+func TestExitErrorFields(t *testing.T) {
+	err := WithAttrs("failed", slog.String("repo", "octocat/hello-world"), slog.Int("build", 42))
+
+	assert.Equal(t, []slog.Attr{slog.String("repo", "octocat/hello-world"), slog.Int("build", 42)}, err.Attrs())
+	assert.Equal(t, logrus.Fields{"repo": "octocat/hello-world", "build": int64(42)}, err.Fields())
+
+	assert.Nil(t, ExitMessage("failed").Fields())
+}
+
+// Test the exit code and error are reported through a slog logger
+func TestHandleExitWithSlog(t *testing.T) {
+	code := -1
+
+	exiter := OsExiter
+	OsExiter = func(c int) { code = c }
+	defer func() { OsExiter = exiter }()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	HandleExitWithSlog(nil, logger)
+	assert.Equal(t, -1, code)
+	assert.Empty(t, buf.String())
+
+	HandleExitWithSlog(WithAttrs("failed", slog.String("repo", "octocat/hello-world")), logger)
+	assert.Equal(t, 1, code)
+
+	record := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "failed", record["msg"])
+	assert.Equal(t, "octocat/hello-world", record["repo"])
+}
+
+// Test the exit code and error are reported through a logrus logger
+func TestHandleExitWithLogger(t *testing.T) {
+	code := -1
+
+	exiter := OsExiter
+	OsExiter = func(c int) { code = c }
+	defer func() { OsExiter = exiter }()
+
+	logger, hook := test.NewNullLogger()
+
+	HandleExitWithLogger(WithAttrs("failed", slog.String("repo", "octocat/hello-world")), logger)
+	assert.Equal(t, 1, code)
+
+	if assert.NotNil(t, hook.LastEntry()) {
+		assert.Equal(t, "failed", hook.LastEntry().Message)
+		assert.Equal(t, "octocat/hello-world", hook.LastEntry().Data["repo"])
+	}
+}
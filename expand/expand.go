@@ -0,0 +1,510 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package expand provides shell-style variable expansion for plugin settings.
+//
+// Not every host CI substitutes variables within settings, so plugins can
+// expand values like ${DRONE_TAG##v}-${DRONE_COMMIT_SHA:0:8} themselves. The
+// following forms are supported, where patterns are shell globs:
+//
+//	$VAR, ${VAR}       value of VAR
+//	${#VAR}            length of VAR
+//	${VAR:-word}       word if VAR is unset or empty
+//	${VAR-word}        word if VAR is unset
+//	${VAR:+word}       word if VAR is set and not empty
+//	${VAR+word}        word if VAR is set
+//	${VAR#pattern}     remove shortest matching prefix
+//	${VAR##pattern}    remove longest matching prefix
+//	${VAR%pattern}     remove shortest matching suffix
+//	${VAR%%pattern}    remove longest matching suffix
+//	${VAR:offset}      substring from offset, negative counts from the end
+//	${VAR:offset:len}  substring of len characters from offset
+//	${VAR^} ${VAR^^}   uppercase first or all characters
+//	${VAR,} ${VAR,,}   lowercase first or all characters
+//	${VAR/pat/rep}     replace first match, // for all, /# prefix, /% suffix
+//	$$                 literal $
+package expand
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// Lookup returns the value of a variable and whether it is set.
+type Lookup func(name string) (string, bool)
+
+// Expand expands the variables within s using the lookup.
+func Expand(s string, lookup Lookup) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		switch next := s[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end, err := closingBrace(s, i+2)
+			if err != nil {
+				return "", err
+			}
+
+			value, err := expandBraces(s[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(value)
+			i = end
+		case isNameStart(next):
+			end := i + 2
+			for end < len(s) && isName(s[end]) {
+				end++
+			}
+
+			value, _ := lookup(s[i+1 : end])
+			b.WriteString(value)
+			i = end - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Environ returns a Lookup for the environment of the pipeline, falling back
+// to the process environment.
+func Environ(p drone.Pipeline) Lookup {
+	environ := p.EnvironMap()
+
+	return func(name string) (string, bool) {
+		if value, ok := environ[name]; ok {
+			return value, true
+		}
+
+		return os.LookupEnv(name)
+	}
+}
+
+// Pipeline expands the variables within s using the environment of the
+// pipeline.
+func Pipeline(s string, p drone.Pipeline) (string, error) {
+	return Expand(s, Environ(p))
+}
+
+// expandBraces expands the expression within ${}.
+func expandBraces(expr string, lookup Lookup) (string, error) {
+	if strings.HasPrefix(expr, "#") && isVariable(expr[1:]) {
+		value, _ := lookup(expr[1:])
+		return strconv.Itoa(utf8.RuneCountInString(value)), nil
+	}
+
+	end := 0
+	for end < len(expr) && isName(expr[end]) {
+		end++
+	}
+
+	name, op := expr[:end], expr[end:]
+	if !isVariable(name) {
+		return "", fmt.Errorf("bad substitution ${%s}", expr)
+	}
+
+	value, set := lookup(name)
+
+	word := func(prefix string) (string, error) {
+		return Expand(strings.TrimPrefix(op, prefix), lookup)
+	}
+
+	switch {
+	case op == "":
+		return value, nil
+	case strings.HasPrefix(op, ":-"):
+		if value == "" {
+			return word(":-")
+		}
+
+		return value, nil
+	case strings.HasPrefix(op, "-"):
+		if !set {
+			return word("-")
+		}
+
+		return value, nil
+	case strings.HasPrefix(op, ":+"):
+		if value != "" {
+			return word(":+")
+		}
+
+		return "", nil
+	case strings.HasPrefix(op, "+"):
+		if set {
+			return word("+")
+		}
+
+		return "", nil
+	case strings.HasPrefix(op, "#"):
+		operator := "#"
+		if strings.HasPrefix(op, "##") {
+			operator = "##"
+		}
+
+		pattern, err := word(operator)
+		if err != nil {
+			return "", err
+		}
+
+		return trimPrefix(value, pattern, operator == "##")
+	case strings.HasPrefix(op, "%"):
+		operator := "%"
+		if strings.HasPrefix(op, "%%") {
+			operator = "%%"
+		}
+
+		pattern, err := word(operator)
+		if err != nil {
+			return "", err
+		}
+
+		return trimSuffix(value, pattern, operator == "%%")
+	case op == "^^":
+		return strings.ToUpper(value), nil
+	case op == "^":
+		return mapFirst(value, strings.ToUpper), nil
+	case op == ",,":
+		return strings.ToLower(value), nil
+	case op == ",":
+		return mapFirst(value, strings.ToLower), nil
+	case strings.HasPrefix(op, "/"):
+		return replace(value, op[1:], lookup)
+	case strings.HasPrefix(op, ":"):
+		return substring(value, op[1:], lookup)
+	}
+
+	return "", fmt.Errorf("bad substitution ${%s}", expr)
+}
+
+// closingBrace returns the index of the brace closing the expression
+// starting at start.
+func closingBrace(s string, start int) (int, error) {
+	depth := 1
+
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("missing closing brace in %q", s[start-2:])
+}
+
+// substring returns the substring of value for the offset and optional
+// length within arg.
+func substring(value, arg string, lookup Lookup) (string, error) {
+	arg, err := Expand(arg, lookup)
+	if err != nil {
+		return "", err
+	}
+
+	offsetArg, lengthArg, hasLength := strings.Cut(arg, ":")
+
+	offset, err := strconv.Atoi(strings.TrimSpace(offsetArg))
+	if err != nil {
+		return "", fmt.Errorf("bad substring offset %q", offsetArg)
+	}
+
+	runes := []rune(value)
+
+	if offset < 0 {
+		offset += len(runes)
+	}
+
+	if offset < 0 || offset > len(runes) {
+		return "", nil
+	}
+
+	end := len(runes)
+
+	if hasLength {
+		length, err := strconv.Atoi(strings.TrimSpace(lengthArg))
+		if err != nil {
+			return "", fmt.Errorf("bad substring length %q", lengthArg)
+		}
+
+		if length < 0 {
+			end += length
+		} else if offset+length < end {
+			end = offset + length
+		}
+
+		if end < offset {
+			return "", fmt.Errorf("substring length %d out of range", length)
+		}
+	}
+
+	return string(runes[offset:end]), nil
+}
+
+// replace replaces the pattern within value as given by arg, which is the
+// pattern and replacement separated by a slash.
+func replace(value, arg string, lookup Lookup) (string, error) {
+	all, prefix, suffix := false, false, false
+
+	switch {
+	case strings.HasPrefix(arg, "/"):
+		all, arg = true, arg[1:]
+	case strings.HasPrefix(arg, "#"):
+		prefix, arg = true, arg[1:]
+	case strings.HasPrefix(arg, "%"):
+		suffix, arg = true, arg[1:]
+	}
+
+	patternArg, replacementArg := splitPattern(arg)
+
+	pattern, err := Expand(patternArg, lookup)
+	if err != nil {
+		return "", err
+	}
+
+	replacement, err := Expand(replacementArg, lookup)
+	if err != nil {
+		return "", err
+	}
+
+	if pattern == "" {
+		return value, nil
+	}
+
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case prefix:
+		for _, end := range reverse(boundaries(value)) {
+			if re.MatchString(value[:end]) {
+				return replacement + value[end:], nil
+			}
+		}
+
+		return value, nil
+	case suffix:
+		for _, start := range boundaries(value) {
+			if re.MatchString(value[start:]) {
+				return value[:start] + replacement, nil
+			}
+		}
+
+		return value, nil
+	}
+
+	var b strings.Builder
+
+	bounds := boundaries(value)
+	last := 0
+
+	for i := 0; i < len(bounds)-1; i++ {
+		start := bounds[i]
+		if start < last {
+			continue
+		}
+
+		matched := false
+
+		for j := len(bounds) - 1; j > i; j-- {
+			if re.MatchString(value[start:bounds[j]]) {
+				b.WriteString(value[last:start])
+				b.WriteString(replacement)
+				last, matched = bounds[j], true
+
+				break
+			}
+		}
+
+		if matched && !all {
+			break
+		}
+	}
+
+	b.WriteString(value[last:])
+
+	return b.String(), nil
+}
+
+// splitPattern splits arg at the first unescaped slash into the pattern and
+// replacement.
+func splitPattern(arg string) (string, string) {
+	for i := 0; i < len(arg); i++ {
+		switch arg[i] {
+		case '\\':
+			i++
+		case '/':
+			return arg[:i], arg[i+1:]
+		}
+	}
+
+	return arg, ""
+}
+
+// trimPrefix removes the shortest or longest prefix matching the pattern.
+func trimPrefix(value, pattern string, longest bool) (string, error) {
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	bounds := boundaries(value)
+	if longest {
+		bounds = reverse(bounds)
+	}
+
+	for _, end := range bounds {
+		if re.MatchString(value[:end]) {
+			return value[end:], nil
+		}
+	}
+
+	return value, nil
+}
+
+// trimSuffix removes the shortest or longest suffix matching the pattern.
+func trimSuffix(value, pattern string, longest bool) (string, error) {
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	bounds := boundaries(value)
+	if !longest {
+		bounds = reverse(bounds)
+	}
+
+	for _, start := range bounds {
+		if re.MatchString(value[start:]) {
+			return value[:start], nil
+		}
+	}
+
+	return value, nil
+}
+
+// globRegexp converts the shell glob pattern into an anchored regexp.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+
+	b.WriteString(`^(?s:`)
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end == -1 {
+				b.WriteString(`\[`)
+				continue
+			}
+
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString(`)$`)
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("bad pattern %q: %w", pattern, err)
+	}
+
+	return re, nil
+}
+
+// boundaries returns the byte offsets of the characters within s, including
+// the end of s.
+func boundaries(s string) []int {
+	bounds := make([]int, 0, len(s)+1)
+
+	for i := range s {
+		bounds = append(bounds, i)
+	}
+
+	return append(bounds, len(s))
+}
+
+// reverse returns the offsets in reverse order.
+func reverse(bounds []int) []int {
+	reversed := make([]int, len(bounds))
+
+	for i, bound := range bounds {
+		reversed[len(bounds)-1-i] = bound
+	}
+
+	return reversed
+}
+
+// mapFirst applies fn to the first character of s.
+func mapFirst(s string, fn func(string) string) string {
+	_, size := utf8.DecodeRuneInString(s)
+	return fn(s[:size]) + s[size:]
+}
+
+// isVariable checks if s is a valid variable name.
+func isVariable(s string) bool {
+	if s == "" || !isNameStart(s[0]) {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		if !isName(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isNameStart checks if c can start a variable name.
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isName checks if c can be part of a variable name.
+func isName(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,79 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test expanding the supported forms
+func TestExpand(t *testing.T) {
+	env := map[string]string{
+		"TAG":   "v1.2.3",
+		"SHA":   "1f3b5e7a9c2d4f6a",
+		"REPO":  "octocat/hello-world",
+		"NAME":  "octocat",
+		"EMPTY": "",
+	}
+
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	tests := map[string]string{
+		"$TAG":                     "v1.2.3",
+		"${TAG}-x":                 "v1.2.3-x",
+		"${#NAME}":                 "7",
+		"${MISSING:-latest}":       "latest",
+		"${EMPTY:-latest}":         "latest",
+		"${EMPTY-latest}":          "",
+		"${MISSING-${NAME}}":       "octocat",
+		"${TAG:+tagged}":           "tagged",
+		"${EMPTY+set}":             "set",
+		"${TAG##v}":                "1.2.3",
+		"${TAG#*.}":                "2.3",
+		"${TAG##*.}":               "3",
+		"${TAG%.*}":                "v1.2",
+		"${TAG%%.*}":               "v1",
+		"${SHA:0:8}":               "1f3b5e7a",
+		"${SHA: -4}":               "4f6a",
+		"${SHA:12}":                "4f6a",
+		"${NAME^}":                 "Octocat",
+		"${NAME^^}":                "OCTOCAT",
+		"${REPO/\\//-}":            "octocat-hello-world",
+		"${TAG//./_}":              "v1_2_3",
+		"${TAG/#v/version-}":       "version-1.2.3",
+		"${TAG/%3/4}":              "v1.2.4",
+		"${REPO/hello*/world}":     "octocat/world",
+		"cost $$5":                 "cost $5",
+		"${TAG##v}-${SHA:0:8}":     "1.2.3-1f3b5e7a",
+		"$":                        "$",
+		"a $ b":                    "a $ b",
+		"${NAME,,}":                "octocat",
+		"${REPO/[!a-z]/_}":         "octocat_hello-world",
+		"${MISSING:-${TAG%%.*}}.x": "v1.x",
+	}
+
+	for input, expected := range tests {
+		actual, err := Expand(input, lookup)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, actual, input)
+	}
+
+	for _, input := range []string{"${TAG", "${1TAG}", "${TAG@Q}", "${TAG:x}"} {
+		_, err := Expand(input, lookup)
+		assert.Error(t, err, input)
+	}
+}
+
+// Test expanding against the pipeline environment
+func TestPipeline(t *testing.T) {
+	actual, err := Pipeline("${DRONE_REPO_NAME}:${DRONE_TAG##v}", drone.Pipeline{
+		Repo:  drone.Repo{Name: "hello-world"},
+		Build: drone.Build{Tag: "v1.0.0"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world:1.0.0", actual)
+}
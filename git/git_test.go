@@ -0,0 +1,109 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test writing the netrc file
+func TestWriteNetrc(t *testing.T) {
+	defer secret.Reset()
+
+	path := filepath.Join(t.TempDir(), ".netrc")
+	assert.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+	err := WriteNetrc(path, drone.Netrc{
+		Machine:  "github.com",
+		Username: "octocat",
+		Password: "hunter2",
+	})
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "machine github.com\nlogin octocat\npassword hunter2\n", string(b))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assert.Error(t, WriteNetrc(path, drone.Netrc{Machine: "github.com", Password: "a b"}))
+
+	cleanup.Run()
+
+	b, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(b))
+
+	info, err = os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
+
+// Test a netrc file written by the plugin is removed on cleanup
+func TestWriteNetrcRemove(t *testing.T) {
+	defer secret.Reset()
+
+	path := filepath.Join(t.TempDir(), ".netrc")
+
+	assert.NoError(t, WriteNetrc(path, drone.Netrc{Machine: "github.com", Password: "hunter2"}))
+	assert.FileExists(t, path)
+
+	cleanup.Run()
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+// Test installing a ssh key and removing it on cleanup
+func TestSetupSSH(t *testing.T) {
+	defer secret.Reset()
+
+	s, err := SetupSSH(`-----BEGIN KEY-----\nabc\n-----END KEY-----`, "github.com ssh-ed25519 AAAA")
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(s.KeyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "-----BEGIN KEY-----\nabc\n-----END KEY-----\n", string(b))
+
+	command := s.Command()
+	assert.True(t, strings.HasPrefix(command, "ssh -i '"+s.KeyFile+"'"))
+	assert.Contains(t, command, "StrictHostKeyChecking=yes")
+	assert.Contains(t, command, "UserKnownHostsFile='"+s.KnownHostsFile+"'")
+
+	cleanup.Run()
+
+	_, err = os.Stat(s.Dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+// Test host key verification is only turned off when asked for
+func TestSetupSSHInsecure(t *testing.T) {
+	defer secret.Reset()
+	defer cleanup.Run()
+
+	_, err := SetupSSH(`-----BEGIN KEY-----\nabc\n-----END KEY-----`, "")
+	assert.Error(t, err)
+
+	logger, hook := test.NewNullLogger()
+
+	s, err := SetupSSHInsecure(drone.WithLogger(context.Background(), logger), `-----BEGIN KEY-----\nabc\n-----END KEY-----`)
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh host key verification is turned off", hook.LastEntry().Message)
+	assert.True(t, s.Insecure)
+	assert.Empty(t, s.KnownHostsFile)
+	assert.Contains(t, s.Command(), "StrictHostKeyChecking=no")
+
+	s = &SSH{KeyFile: s.KeyFile}
+	assert.Contains(t, s.Command(), "StrictHostKeyChecking=yes")
+	assert.NotContains(t, s.Command(), "UserKnownHostsFile")
+}
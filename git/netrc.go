@@ -0,0 +1,107 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package git provides credential helpers for plugins working with git.
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+)
+
+// NetrcPath returns the path of the netrc file within the home directory.
+func NetrcPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory for netrc: %w", err)
+	}
+
+	name := ".netrc"
+	if runtime.GOOS == "windows" {
+		name = "_netrc"
+	}
+
+	return filepath.Join(home, name), nil
+}
+
+// WriteNetrc writes the credentials to the netrc file at path.
+//
+// The file is only readable by the current user. Nothing is written if the
+// machine is empty. When the plugin exits the previous content of the file
+// is restored, or the file is removed if it did not exist.
+func WriteNetrc(path string, netrc drone.Netrc) error {
+	if netrc.Machine == "" {
+		return nil
+	}
+
+	for name, value := range map[string]string{
+		"machine":  netrc.Machine,
+		"username": netrc.Username,
+		"password": netrc.Password,
+	} {
+		if strings.ContainsAny(value, " \t\r\n") {
+			return fmt.Errorf("netrc %s cannot contain whitespace", name)
+		}
+	}
+
+	secret.Register(netrc.Password)
+
+	content := fmt.Sprintf(
+		"machine %s\nlogin %s\npassword %s\n",
+		netrc.Machine,
+		netrc.Username,
+		netrc.Password,
+	)
+
+	restore, err := backupFile(path)
+	if err != nil {
+		return err
+	}
+
+	cleanup.Register(restore)
+
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return fmt.Errorf("failed to write netrc file %s: %w", path, err)
+	}
+
+	// Tighten the permissions of an existing file.
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("failed to set permissions of netrc file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// backupFile reads the file at path, returning a function to restore it. The
+// function removes the file if it did not exist.
+func backupFile(path string) (func(), error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return func() {
+			_ = os.Remove(path)
+		}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read netrc file %s: %w", path, err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read netrc file %s: %w", path, err)
+	}
+
+	return func() {
+		_ = os.WriteFile(path, b, info.Mode().Perm())
+		_ = os.Chmod(path, info.Mode().Perm())
+	}, nil
+}
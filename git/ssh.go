@@ -0,0 +1,165 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+)
+
+// SSHCommandEnv is the environment variable git reads the ssh command from.
+const SSHCommandEnv = "GIT_SSH_COMMAND"
+
+// SSH is a private key and known hosts installed for git.
+type SSH struct {
+	// Dir containing the installed files.
+	Dir string
+
+	// KeyFile is the path of the private key.
+	KeyFile string
+
+	// KnownHostsFile is the path of the known hosts, empty if no known
+	// hosts were given.
+	KnownHostsFile string
+
+	// Insecure turns off verifying the host key of the remote.
+	Insecure bool
+}
+
+// SetupSSH installs the private key and known hosts entries into a temporary
+// directory.
+//
+// The directory is removed when the plugin exits. The host key of the remote
+// is verified against the known hosts, so an error is returned if none are
+// given. Use SetupSSHInsecure to connect without verifying the host key.
+func SetupSSH(key, knownHosts string) (*SSH, error) {
+	if strings.TrimSpace(knownHosts) == "" {
+		return nil, fmt.Errorf("ssh known hosts are empty")
+	}
+
+	return setupSSH(key, knownHosts)
+}
+
+// SetupSSHInsecure installs the private key into a temporary directory
+// without any known hosts.
+//
+// The host key of the remote is not verified, leaving the connection open to
+// man in the middle attacks. Only use this when the plugin is explicitly
+// configured to skip verification. A warning is logged through the logger
+// carried by ctx.
+func SetupSSHInsecure(ctx context.Context, key string) (*SSH, error) {
+	s, err := setupSSH(key, "")
+	if err != nil {
+		return nil, err
+	}
+
+	drone.LoggerFromContext(ctx).Warning("ssh host key verification is turned off")
+	s.Insecure = true
+
+	return s, nil
+}
+
+// setupSSH installs the private key and the known hosts if given.
+func setupSSH(key, knownHosts string) (*SSH, error) {
+	if strings.TrimSpace(key) == "" {
+		return nil, fmt.Errorf("ssh key is empty")
+	}
+
+	secret.Register(key)
+
+	dir, err := os.MkdirTemp("", "drone-ssh-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssh directory: %w", err)
+	}
+
+	s := &SSH{
+		Dir:     dir,
+		KeyFile: filepath.Join(dir, "id"),
+	}
+
+	cleanup.Register(func() {
+		_ = s.Close()
+	})
+
+	if err := os.WriteFile(s.KeyFile, []byte(normalize(key)), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write ssh key: %w", err)
+	}
+
+	if strings.TrimSpace(knownHosts) != "" {
+		s.KnownHostsFile = filepath.Join(dir, "known_hosts")
+
+		if err := os.WriteFile(s.KnownHostsFile, []byte(normalize(knownHosts)), 0600); err != nil {
+			return nil, fmt.Errorf("failed to write ssh known hosts: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// Command returns the ssh command git should use.
+func (s *SSH) Command() string {
+	args := []string{
+		"ssh",
+		"-i", quote(s.KeyFile),
+		"-o", "IdentitiesOnly=yes",
+	}
+
+	switch {
+	case s.KnownHostsFile != "":
+		args = append(args,
+			"-o", "UserKnownHostsFile="+quote(s.KnownHostsFile),
+			"-o", "StrictHostKeyChecking=yes",
+		)
+	case s.Insecure:
+		args = append(args,
+			"-o", "UserKnownHostsFile=/dev/null",
+			"-o", "StrictHostKeyChecking=no",
+		)
+	default:
+		args = append(args,
+			"-o", "StrictHostKeyChecking=yes",
+		)
+	}
+
+	return strings.Join(args, " ")
+}
+
+// Environ returns the GIT_SSH_COMMAND environment variable in the form
+// "key=value".
+func (s *SSH) Environ() []string {
+	return []string{SSHCommandEnv + "=" + s.Command()}
+}
+
+// Close removes the installed files.
+func (s *SSH) Close() error {
+	if err := os.RemoveAll(s.Dir); err != nil {
+		return fmt.Errorf("failed to remove ssh directory %s: %w", s.Dir, err)
+	}
+
+	return nil
+}
+
+// normalize converts escaped newlines, as often found in settings, and adds
+// the trailing newline ssh requires.
+func normalize(value string) string {
+	if !strings.Contains(value, "\n") {
+		value = strings.ReplaceAll(value, `\n`, "\n")
+	}
+
+	return strings.TrimRight(value, "\n") + "\n"
+}
+
+// quote quotes the value for the shell git runs the ssh command with.
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
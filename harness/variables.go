@@ -2,12 +2,16 @@ package harness
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
 	v3 "github.com/harness/godotenv/v3"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -30,50 +34,113 @@ const (
 	HarnessOutputSecretFile = "HARNESS_OUTPUT_SECRET_FILE"
 )
 
+// Files writes the output files, logging changes to Logger.
+//
+// The package level functions use Files with the logrus standard logger.
+// Use FilesFromContext to log to the logger carried by a context instead.
+type Files struct {
+	// Logger for changes to the files, the logrus standard logger if nil.
+	Logger *logrus.Logger
+}
+
+// FilesFromContext creates Files logging to the logger carried by the
+// context.
+func FilesFromContext(ctx context.Context) Files {
+	return Files{Logger: drone.LoggerFromContext(ctx)}
+}
+
 // SetSecret sets a new secret by adding it to the HARNESS_OUTPUT_SECRET_FILE file
+//
+// The value is also registered so it is masked in all log output.
 func SetSecret(name, value string) error {
-	return UpdateOrRemoveKeyValue(HarnessOutputSecretFile, name, value, false)
+	return Files{}.SetSecret(name, value)
 }
 
 // UpdateSecret overwrites the value of an existing secret.
 func UpdateSecret(name, value string) error {
-	return UpdateOrRemoveKeyValue(HarnessOutputSecretFile, name, value, false)
+	return Files{}.UpdateSecret(name, value)
 }
 
 // DeleteSecret removes a secret from the file entirely.
 func DeleteSecret(name string) error {
-	return UpdateOrRemoveKeyValue(HarnessOutputSecretFile, name, "", true)
+	return Files{}.DeleteSecret(name)
 }
 
 // SetOutput sets a new secret by adding it to the DRONE_OUTPUT file
 func SetOutput(name, value string) error {
-	return UpdateOrRemoveKeyValue(DroneOutputFile, name, value, false)
+	return Files{}.SetOutput(name, value)
 }
 
 // UpdateOutput overwrites the value of an existing output.
 func UpdateOutput(name, value string) error {
-	return UpdateOrRemoveKeyValue(DroneOutputFile, name, value, false)
+	return Files{}.UpdateOutput(name, value)
 }
 
 // DeleteOutput removes an output from the file entirely.
 func DeleteOutput(name string) error {
-	return UpdateOrRemoveKeyValue(DroneOutputFile, name, "", true)
+	return Files{}.DeleteOutput(name)
 }
 
 // SetErrorMetadata sets the error message, error code, and error category, writing them to the CI_ERROR_METADATA file
 func SetErrorMetadata(message, code, category string) error {
+	return Files{}.SetErrorMetadata(message, code, category)
+}
+
+// UpdateOrRemoveKeyValue updates or deletes a key-value pair in the specified file.
+func UpdateOrRemoveKeyValue(envVar, key, newValue string, deleteKey bool) error {
+	return Files{}.UpdateOrRemoveKeyValue(envVar, key, newValue, deleteKey)
+}
+
+// SetSecret sets a new secret by adding it to the HARNESS_OUTPUT_SECRET_FILE file
+//
+// The value is also registered so it is masked in all log output.
+func (f Files) SetSecret(name, value string) error {
+	secret.Register(value)
+
+	return f.UpdateOrRemoveKeyValue(HarnessOutputSecretFile, name, value, false)
+}
+
+// UpdateSecret overwrites the value of an existing secret.
+func (f Files) UpdateSecret(name, value string) error {
+	secret.Register(value)
+
+	return f.UpdateOrRemoveKeyValue(HarnessOutputSecretFile, name, value, false)
+}
+
+// DeleteSecret removes a secret from the file entirely.
+func (f Files) DeleteSecret(name string) error {
+	return f.UpdateOrRemoveKeyValue(HarnessOutputSecretFile, name, "", true)
+}
+
+// SetOutput sets a new output by adding it to the DRONE_OUTPUT file
+func (f Files) SetOutput(name, value string) error {
+	return f.UpdateOrRemoveKeyValue(DroneOutputFile, name, value, false)
+}
+
+// UpdateOutput overwrites the value of an existing output.
+func (f Files) UpdateOutput(name, value string) error {
+	return f.UpdateOrRemoveKeyValue(DroneOutputFile, name, value, false)
+}
+
+// DeleteOutput removes an output from the file entirely.
+func (f Files) DeleteOutput(name string) error {
+	return f.UpdateOrRemoveKeyValue(DroneOutputFile, name, "", true)
+}
+
+// SetErrorMetadata sets the error message, error code, and error category, writing them to the CI_ERROR_METADATA file
+func (f Files) SetErrorMetadata(message, code, category string) error {
 	// Write the error message
-	if err := UpdateOrRemoveKeyValue(MetadataFile, ErrorMessageKey, message, false); err != nil {
+	if err := f.UpdateOrRemoveKeyValue(MetadataFile, ErrorMessageKey, message, false); err != nil {
 		return err
 	}
 
 	// Write the error code
-	if err := UpdateOrRemoveKeyValue(MetadataFile, ErrorCodeKey, code, false); err != nil {
+	if err := f.UpdateOrRemoveKeyValue(MetadataFile, ErrorCodeKey, code, false); err != nil {
 		return err
 	}
 
 	// Write the error category
-	if err := UpdateOrRemoveKeyValue(MetadataFile, ErrorCategoryKey, category, false); err != nil {
+	if err := f.UpdateOrRemoveKeyValue(MetadataFile, ErrorCategoryKey, category, false); err != nil {
 		return err
 	}
 
@@ -81,7 +148,7 @@ func SetErrorMetadata(message, code, category string) error {
 }
 
 // UpdateOrRemoveKeyValue updates or deletes a key-value pair in the specified file.
-func UpdateOrRemoveKeyValue(envVar, key, newValue string, deleteKey bool) error {
+func (f Files) UpdateOrRemoveKeyValue(envVar, key, newValue string, deleteKey bool) error {
 	filePath := os.Getenv(envVar)
 	if filePath == "" {
 		return fmt.Errorf("environment variable %s is not set", envVar)
@@ -95,6 +162,12 @@ func UpdateOrRemoveKeyValue(envVar, key, newValue string, deleteKey bool) error
 		}
 	}
 
+	f.logger().WithFields(logrus.Fields{
+		"file":   filePath,
+		"key":    key,
+		"delete": deleteKey,
+	}).Debug("updating key value file")
+
 	// Trim trailing newline characters from newValue
 	newValue = strings.TrimRight(newValue, "\n")
 
@@ -157,6 +230,15 @@ func UpdateOrRemoveKeyValue(envVar, key, newValue string, deleteKey bool) error
 	return nil
 }
 
+// logger returns the logger for changes to the files.
+func (f Files) logger() *logrus.Logger {
+	if f.Logger == nil {
+		return logrus.StandardLogger()
+	}
+
+	return f.Logger
+}
+
 // ReadLines reads lines from a file and returns them as a slice of strings.
 func ReadLines(filename string) ([]string, error) {
 	file, err := os.Open(filename)
@@ -1,10 +1,15 @@
 package harness
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/drone-plugins/drone-plugin-lib/drone"
 	v3 "github.com/harness/godotenv/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -216,3 +221,18 @@ func TestFileNotExistError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "environment variable HARNESS_OUTPUT_SECRET_FILE is not set")
 }
+
+// Test changes are logged to the logger carried by the context
+func TestFilesFromContext(t *testing.T) {
+	t.Setenv("DRONE_OUTPUT", filepath.Join(t.TempDir(), "output.env"))
+
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	files := FilesFromContext(drone.WithLogger(context.Background(), logger))
+	assert.NoError(t, files.SetOutput("KEY", "value"))
+
+	if assert.Len(t, hook.AllEntries(), 1) {
+		assert.Equal(t, "KEY", hook.LastEntry().Data["key"])
+	}
+}
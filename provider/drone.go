@@ -0,0 +1,34 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package provider
+
+import (
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// Drone detects Drone, which provides the DRONE_* variables the pipeline is
+// read from so nothing is filled.
+type Drone struct{}
+
+// Kind returns drone.HostDrone.
+func (Drone) Kind() drone.HostKind {
+	return drone.HostDrone
+}
+
+// Detect checks if the plugin is running within Drone.
+func (Drone) Detect() bool {
+	return env("DRONE") == "true"
+}
+
+// Version returns the version of the Drone server.
+func (Drone) Version() string {
+	return env("DRONE_SYSTEM_VERSION")
+}
+
+// Fill leaves the pipeline as is.
+func (Drone) Fill(p *drone.Pipeline) error {
+	return nil
+}
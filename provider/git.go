@@ -0,0 +1,183 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// Git fills the pipeline from the local git repository when running outside
+// of a CI system, such as a developer running a plugin from a checkout.
+//
+// The repository containing the workspace, by default the working
+// directory, is inspected by executing git. Nothing is filled outside of a
+// repository.
+type Git struct{}
+
+// Kind returns drone.HostLocal.
+func (Git) Kind() drone.HostKind {
+	return drone.HostLocal
+}
+
+// Detect checks if git is available.
+func (Git) Detect() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// Version returns an empty version as there is no host CI.
+func (Git) Version() string {
+	return ""
+}
+
+// Fill sets the missing fields of the pipeline from the local repository.
+func (Git) Fill(p *drone.Pipeline) error {
+	dir := p.Workspace.Root()
+
+	if inside, err := git(dir, "rev-parse", "--is-inside-work-tree"); err != nil || inside != "true" {
+		return nil
+	}
+
+	sha, err := git(dir, "rev-parse", "HEAD")
+	if err != nil {
+		// A repository without commits.
+		return nil
+	}
+
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return err
+	}
+
+	branch, _ := git(dir, "symbolic-ref", "--short", "-q", "HEAD")
+
+	tags, err := git(dir, "tag", "--points-at", "HEAD", "--sort=-version:refname")
+	if err != nil {
+		return err
+	}
+
+	tag, _, _ := strings.Cut(tags, "\n")
+
+	commit, err := git(dir, "log", "-1", "--format=%an%x00%ae%x00%B")
+	if err != nil {
+		return err
+	}
+
+	fields := strings.SplitN(commit, "\x00", 3)
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+
+	fillString(&p.Build.Branch, branch)
+	fillString(&p.Build.Tag, tag)
+
+	switch {
+	case branch != "":
+		fillString(&p.Build.Event, drone.EventPush)
+		fillString(&p.Commit.Ref, "refs/heads/"+branch)
+	case tag != "":
+		fillString(&p.Build.Event, drone.EventTag)
+		fillString(&p.Commit.Ref, "refs/tags/"+tag)
+	}
+
+	fillString(&p.Commit.SHA, sha)
+	fillString(&p.Commit.After, sha)
+	fillString(&p.Commit.Branch, branch)
+	fillString(&p.Commit.Author.Name, fields[0])
+	fillString(&p.Commit.Author.Email, fields[1])
+	fillMessage(&p.Commit.Message, fields[2])
+
+	fillString(&p.Repo.SCM, "git")
+	fillGitRemote(&p.Repo, dir, branch)
+
+	fillString(&p.Workspace.Dir, top)
+
+	return nil
+}
+
+// fillGitRemote sets the missing fields of the repository from the remote
+// of the branch, falling back to origin.
+func fillGitRemote(repo *drone.Repo, dir, branch string) {
+	name, err := git(dir, "config", "--get", "branch."+branch+".remote")
+	if err != nil || name == "" || name == "." {
+		name = "origin"
+	}
+
+	remote, err := git(dir, "remote", "get-url", name)
+	if err != nil || remote == "" {
+		return
+	}
+
+	host, slug := parseRemote(remote)
+	if slug == "" {
+		return
+	}
+
+	owner, repoName := slug, slug
+	if i := strings.LastIndex(slug, "/"); i != -1 {
+		owner, repoName = slug[:i], slug[i+1:]
+	}
+
+	fillString(&repo.Slug, slug)
+	fillString(&repo.Owner, owner)
+	fillString(&repo.Name, repoName)
+
+	if host != "" {
+		fillString(&repo.Link, "https://"+host+"/"+slug)
+	}
+
+	if strings.HasPrefix(remote, "http://") || strings.HasPrefix(remote, "https://") {
+		fillString(&repo.HTTPURL, withoutCredentials(remote))
+	} else {
+		fillString(&repo.SSHURL, remote)
+
+		if host != "" {
+			fillString(&repo.HTTPURL, "https://"+host+"/"+slug+".git")
+		}
+	}
+}
+
+// parseRemote returns the host and owner/name slug of the remote url, which
+// is either a url or a scp-like ssh address such as git@host:owner/name.git.
+func parseRemote(remote string) (string, string) {
+	var host, path string
+
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if _, rest, ok := strings.Cut(remote, "@"); ok {
+		host, path, _ = strings.Cut(rest, ":")
+	} else if h, p, ok := strings.Cut(remote, ":"); ok && !strings.Contains(h, "/") {
+		host, path = h, p
+	} else {
+		return "", ""
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	return host, path
+}
+
+// git runs git with the args in dir, returning the trimmed output.
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// initRepo creates a repository with a single commit in a temp dir.
+func initRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", append([]string{
+			"-c", "user.name=The Octocat",
+			"-c", "user.email=octocat@github.com",
+			"-c", "commit.gpgsign=false",
+			"-c", "tag.gpgsign=false",
+		}, args...)...)
+		cmd.Dir = dir
+
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "Update README\n\nAdd usage section")
+	run("tag", "v1.0.0")
+	run("remote", "add", "origin", "git@github.com:octocat/hello-world.git")
+
+	return dir
+}
+
+// Test filling the pipeline from a local repository
+func TestGit(t *testing.T) {
+	dronetest.ClearEnv(t)
+
+	dir := initRepo(t)
+
+	pipeline := drone.Pipeline{
+		Workspace: drone.Workspace{Dir: dir},
+	}
+	assert.NoError(t, Git{}.Fill(&pipeline))
+
+	sha, err := git(dir, "rev-parse", "HEAD")
+	assert.NoError(t, err)
+
+	assert.Equal(t, drone.EventPush, pipeline.Build.Event)
+	assert.Equal(t, "main", pipeline.Build.Branch)
+	assert.Equal(t, "v1.0.0", pipeline.Build.Tag)
+	assert.Equal(t, sha, pipeline.Commit.SHA)
+	assert.Equal(t, "refs/heads/main", pipeline.Commit.Ref)
+	assert.Equal(t, drone.Message{Title: "Update README", Body: "Add usage section"}, pipeline.Commit.Message)
+	assert.Equal(t, "The Octocat", pipeline.Commit.Author.Name)
+	assert.Equal(t, "octocat@github.com", pipeline.Commit.Author.Email)
+	assert.Equal(t, "octocat/hello-world", pipeline.Repo.Slug)
+	assert.Equal(t, "octocat", pipeline.Repo.Owner)
+	assert.Equal(t, "hello-world", pipeline.Repo.Name)
+	assert.Equal(t, "git@github.com:octocat/hello-world.git", pipeline.Repo.SSHURL)
+	assert.Equal(t, "https://github.com/octocat/hello-world.git", pipeline.Repo.HTTPURL)
+	assert.Equal(t, "https://github.com/octocat/hello-world", pipeline.Repo.Link)
+}
+
+// Test nothing is filled outside of a repository
+func TestGitOutsideRepository(t *testing.T) {
+	dronetest.ClearEnv(t)
+
+	pipeline := drone.Pipeline{
+		Workspace: drone.Workspace{Dir: t.TempDir()},
+	}
+	assert.NoError(t, Git{}.Fill(&pipeline))
+	assert.Equal(t, drone.Pipeline{Workspace: pipeline.Workspace}, pipeline)
+}
+
+// Test parsing the host and slug of remotes
+func TestParseRemote(t *testing.T) {
+	tests := map[string][2]string{
+		"https://github.com/octocat/hello-world.git":           {"github.com", "octocat/hello-world"},
+		"https://token@gitlab.com/group/sub/hello-world":       {"gitlab.com", "group/sub/hello-world"},
+		"git@github.com:octocat/hello-world.git":               {"github.com", "octocat/hello-world"},
+		"ssh://git@gitea.example.com:2222/octocat/hello-world": {"gitea.example.com", "octocat/hello-world"},
+		"/srv/git/hello-world.git":                             {"", ""},
+	}
+
+	for remote, expected := range tests {
+		host, slug := parseRemote(remote)
+		assert.Equal(t, expected, [2]string{host, slug}, remote)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+const (
+	// inputPrefix is the prefix of the GitHub Actions input variables.
+	inputPrefix = "INPUT_"
+
+	// settingPrefix is the prefix of the plugin setting variables.
+	settingPrefix = "PLUGIN_"
+)
+
+type (
+	// githubEvent is the subset of the GitHub webhook payload read from
+	// GITHUB_EVENT_PATH.
+	githubEvent struct {
+		Action      string             `json:"action"`
+		Before      string             `json:"before"`
+		After       string             `json:"after"`
+		Compare     string             `json:"compare"`
+		HeadCommit  *githubCommit      `json:"head_commit"`
+		PullRequest *githubPullRequest `json:"pull_request"`
+		Repository  githubRepository   `json:"repository"`
+		Sender      githubUser         `json:"sender"`
+	}
+
+	githubCommit struct {
+		ID      string       `json:"id"`
+		Message string       `json:"message"`
+		URL     string       `json:"url"`
+		Author  githubAuthor `json:"author"`
+	}
+
+	githubAuthor struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	}
+
+	githubPullRequest struct {
+		Number  int        `json:"number"`
+		Title   string     `json:"title"`
+		Body    string     `json:"body"`
+		HTMLURL string     `json:"html_url"`
+		User    githubUser `json:"user"`
+		Head    githubRef  `json:"head"`
+		Base    githubRef  `json:"base"`
+	}
+
+	githubRef struct {
+		Ref  string               `json:"ref"`
+		SHA  string               `json:"sha"`
+		Repo githubRepositoryName `json:"repo"`
+	}
+
+	githubRepositoryName struct {
+		FullName string `json:"full_name"`
+	}
+
+	githubUser struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	}
+
+	githubRepository struct {
+		Private       bool   `json:"private"`
+		Visibility    string `json:"visibility"`
+		DefaultBranch string `json:"default_branch"`
+		HTMLURL       string `json:"html_url"`
+		CloneURL      string `json:"clone_url"`
+		SSHURL        string `json:"ssh_url"`
+	}
+)
+
+// GitHub fills the pipeline from the GitHub Actions environment.
+//
+// The GITHUB_* variables are mapped onto the pipeline, along with the author
+// and message of the commit from the webhook payload at GITHUB_EVENT_PATH.
+type GitHub struct{}
+
+// Kind returns drone.HostGitHub.
+func (GitHub) Kind() drone.HostKind {
+	return drone.HostGitHub
+}
+
+// Detect checks if the plugin is running within GitHub Actions.
+func (GitHub) Detect() bool {
+	return env("GITHUB_ACTIONS") == "true"
+}
+
+// Version returns the version of GitHub Actions, which is not provided.
+func (GitHub) Version() string {
+	return ""
+}
+
+// Fill sets the missing fields of the pipeline from the GitHub variables
+// and event payload.
+func (GitHub) Fill(p *drone.Pipeline) error {
+	event, err := readGitHubEvent(env("GITHUB_EVENT_PATH"))
+	if err != nil {
+		return err
+	}
+
+	server := strings.TrimSuffix(env("GITHUB_SERVER_URL"), "/")
+	slug := env("GITHUB_REPOSITORY")
+	owner, name, _ := strings.Cut(slug, "/")
+
+	fillString(&p.Repo.Slug, slug)
+	fillString(&p.Repo.Owner, env("GITHUB_REPOSITORY_OWNER"), owner)
+	fillString(&p.Repo.Name, name)
+	fillString(&p.Repo.SCM, "git")
+	fillString(&p.Repo.Link, event.Repository.HTMLURL, joinNonEmpty(server, slug))
+	fillString(&p.Repo.Branch, event.Repository.DefaultBranch)
+	fillString(&p.Repo.HTTPURL, event.Repository.CloneURL)
+	fillString(&p.Repo.SSHURL, event.Repository.SSHURL)
+	fillString(&p.Repo.Visibility, event.Repository.Visibility)
+
+	if event.Repository.Private {
+		p.Repo.Private = true
+	}
+
+	refType := env("GITHUB_REF_TYPE")
+	refName := env("GITHUB_REF_NAME")
+
+	fillInt(&p.Build.Number, env("GITHUB_RUN_NUMBER"))
+	fillString(&p.Build.Event, githubEventName(env("GITHUB_EVENT_NAME"), refType))
+	fillString(&p.Build.Action, githubAction(event.Action))
+
+	if runID := env("GITHUB_RUN_ID"); runID != "" && slug != "" {
+		fillString(&p.Build.Link, joinNonEmpty(server, slug, "actions", "runs", runID))
+	}
+
+	if refType == "tag" {
+		fillString(&p.Build.Tag, refName)
+	}
+
+	fillString(&p.Commit.Ref, env("GITHUB_REF"))
+	fillString(&p.Commit.Before, event.Before)
+
+	if pr := event.PullRequest; pr != nil {
+		fillInt(&p.Build.PullRequest, strconv.Itoa(pr.Number))
+		fillString(&p.Build.SourceBranch, env("GITHUB_HEAD_REF"), pr.Head.Ref)
+		fillString(&p.Build.TargetBranch, env("GITHUB_BASE_REF"), pr.Base.Ref)
+		fillString(&p.Build.Branch, p.Build.TargetBranch)
+
+		fillString(&p.Commit.SHA, pr.Head.SHA)
+		fillString(&p.Commit.After, pr.Head.SHA)
+		fillString(&p.Commit.Branch, p.Build.TargetBranch)
+		fillString(&p.Commit.Link, pr.HTMLURL)
+		fillString(&p.Commit.Author.Username, pr.User.Login)
+		fillString(&p.Commit.Author.Avatar, pr.User.AvatarURL)
+		fillMessage(&p.Commit.Message, pr.Title+"\n\n"+pr.Body)
+
+		fillString(&p.PullRequest.Title, pr.Title)
+		fillString(&p.PullRequest.Link, pr.HTMLURL)
+		fillString(&p.PullRequest.SourceRepo, pr.Head.Repo.FullName)
+		fillString(&p.PullRequest.TargetRepo, pr.Base.Repo.FullName)
+		fillString(&p.PullRequest.HeadSHA, pr.Head.SHA)
+		fillString(&p.PullRequest.BaseSHA, pr.Base.SHA)
+	} else if refType != "tag" {
+		fillString(&p.Build.Branch, refName)
+		fillString(&p.Commit.Branch, refName)
+	}
+
+	fillString(&p.Commit.SHA, env("GITHUB_SHA"))
+	fillString(&p.Commit.After, event.After, env("GITHUB_SHA"))
+
+	if commit := event.HeadCommit; commit != nil {
+		fillString(&p.Commit.Link, commit.URL)
+		fillString(&p.Commit.Author.Username, commit.Author.Username)
+		fillString(&p.Commit.Author.Name, commit.Author.Name)
+		fillString(&p.Commit.Author.Email, commit.Author.Email)
+		fillMessage(&p.Commit.Message, commit.Message)
+	}
+
+	fillString(&p.Commit.Link, event.Compare)
+	fillString(&p.Commit.Author.Username, env("GITHUB_ACTOR"))
+	fillString(&p.Commit.Author.Avatar, event.Sender.AvatarURL)
+
+	fillString(&p.Stage.Name, env("GITHUB_JOB"))
+	fillString(&p.Stage.OS, strings.ToLower(env("RUNNER_OS")))
+	fillString(&p.Stage.Arch, githubArch(env("RUNNER_ARCH")))
+	fillString(&p.Stage.Machine, env("RUNNER_NAME"))
+	fillString(&p.Step.Name, env("GITHUB_ACTION"))
+
+	if u, err := url.Parse(server); err == nil {
+		fillString(&p.System.Proto, u.Scheme)
+		fillString(&p.System.Host, u.Host)
+	}
+
+	fillString(&p.Workspace.Dir, env("GITHUB_WORKSPACE"))
+
+	return nil
+}
+
+// LoadGitHubInputs sets the PLUGIN_* environment variables from the INPUT_*
+// variables GitHub Actions passes the inputs of a container action as.
+//
+// Hyphens within input names are converted to underscores and variables
+// already present in the environment are not overridden. Environment
+// variables are read when the flags are parsed so this needs to be called
+// before running the cli.App.
+func LoadGitHubInputs() error {
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, inputPrefix) {
+			continue
+		}
+
+		setting := settingPrefix + strings.ReplaceAll(strings.TrimPrefix(name, inputPrefix), "-", "_")
+		if _, ok := os.LookupEnv(setting); ok {
+			continue
+		}
+
+		if err := os.Setenv(setting, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", setting, err)
+		}
+	}
+
+	return nil
+}
+
+// readGitHubEvent reads the webhook payload at path. An empty event is
+// returned if there is no path.
+func readGitHubEvent(path string) (githubEvent, error) {
+	event := githubEvent{}
+
+	if path == "" {
+		return event, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return event, fmt.Errorf("failed to read github event %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, &event); err != nil {
+		return event, fmt.Errorf("failed to parse github event %s: %w", path, err)
+	}
+
+	return event, nil
+}
+
+// githubEventName converts the GitHub event name to a Drone event.
+func githubEventName(name, refType string) drone.Event {
+	switch name {
+	case "push":
+		if refType == "tag" {
+			return drone.EventTag
+		}
+
+		return drone.EventPush
+	case "pull_request", "pull_request_target":
+		return drone.EventPullRequest
+	case "release":
+		return drone.EventTag
+	case "deployment":
+		return drone.EventPromote
+	case "schedule":
+		return drone.EventCron
+	case "":
+		return ""
+	}
+
+	return drone.EventCustom
+}
+
+// githubAction converts the action of the GitHub event to a Drone action.
+func githubAction(action string) drone.Action {
+	switch action {
+	case "synchronize":
+		return drone.ActionSynchronized
+	case "created":
+		return drone.ActionCreated
+	case "deleted":
+		return drone.ActionDeleted
+	}
+
+	return drone.Action(action)
+}
+
+// githubArch converts the runner architecture to a GOARCH value.
+func githubArch(arch string) string {
+	switch arch {
+	case "X64":
+		return "amd64"
+	case "X86":
+		return "386"
+	case "ARM64":
+		return "arm64"
+	case "ARM":
+		return "arm"
+	}
+
+	return strings.ToLower(arch)
+}
+
+// fillMessage sets dst from the full commit message if dst is empty.
+func fillMessage(dst *drone.Message, message string) {
+	if dst.Title != "" || strings.TrimSpace(message) == "" {
+		return
+	}
+
+	*dst = drone.ParseMessage(message)
+}
+
+// joinNonEmpty joins the elements with a slash if none are empty.
+func joinNonEmpty(elems ...string) string {
+	for _, elem := range elems {
+		if elem == "" {
+			return ""
+		}
+	}
+
+	return strings.Join(elems, "/")
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// setGitHubEnv sets the GitHub Actions environment for the event.
+func setGitHubEnv(t *testing.T, event, ref string) {
+	dronetest.ClearEnv(t)
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_EVENT_NAME", event)
+	t.Setenv("GITHUB_EVENT_PATH", "testdata/github/"+event+".json")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "octocat/hello-world")
+	t.Setenv("GITHUB_REPOSITORY_OWNER", "octocat")
+	t.Setenv("GITHUB_REF", ref)
+	t.Setenv("GITHUB_REF_NAME", "main")
+	t.Setenv("GITHUB_REF_TYPE", "branch")
+	t.Setenv("GITHUB_SHA", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	t.Setenv("GITHUB_RUN_ID", "1658821493")
+	t.Setenv("GITHUB_RUN_NUMBER", "12")
+	t.Setenv("GITHUB_JOB", "build")
+	t.Setenv("GITHUB_ACTION", "publish")
+	t.Setenv("GITHUB_WORKSPACE", "/home/runner/work/hello-world/hello-world")
+	t.Setenv("RUNNER_OS", "Linux")
+	t.Setenv("RUNNER_ARCH", "X64")
+}
+
+// Test filling the pipeline from a push event
+func TestGitHubPush(t *testing.T) {
+	setGitHubEnv(t, "push", "refs/heads/main")
+
+	assert.Equal(t, GitHub{}, Detected())
+
+	pipeline := drone.Pipeline{}
+	assert.NoError(t, Fill(&pipeline))
+
+	assert.Equal(t, drone.EventPush, pipeline.Build.Event)
+	assert.Equal(t, 12, pipeline.Build.Number)
+	assert.Equal(t, "main", pipeline.Build.Branch)
+	assert.Equal(t, "https://github.com/octocat/hello-world/actions/runs/1658821493", pipeline.Build.Link)
+	assert.Equal(t, "octocat/hello-world", pipeline.Repo.Slug)
+	assert.Equal(t, "hello-world", pipeline.Repo.Name)
+	assert.Equal(t, "git@github.com:octocat/hello-world.git", pipeline.Repo.SSHURL)
+	assert.Equal(t, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", pipeline.Commit.SHA)
+	assert.Equal(t, "762941318ee16e59dabbacb1b4049eec22f0d303", pipeline.Commit.Before)
+	assert.Equal(t, drone.Message{Title: "Update README", Body: "Add usage section"}, pipeline.Commit.Message)
+	assert.Equal(t, drone.Author{
+		Username: "octocat",
+		Name:     "The Octocat",
+		Email:    "octocat@github.com",
+		Avatar:   "https://avatars.githubusercontent.com/u/583231?v=4",
+	}, pipeline.Commit.Author)
+	assert.Equal(t, "build", pipeline.Stage.Name)
+	assert.Equal(t, "linux", pipeline.Stage.OS)
+	assert.Equal(t, "amd64", pipeline.Stage.Arch)
+	assert.Equal(t, "publish", pipeline.Step.Name)
+	assert.Equal(t, "github.com", pipeline.System.Host)
+	assert.Equal(t, "/home/runner/work/hello-world/hello-world", pipeline.Workspace.Dir)
+}
+
+// Test filling the pipeline from a pull request event
+func TestGitHubPullRequest(t *testing.T) {
+	setGitHubEnv(t, "pull_request", "refs/pull/42/merge")
+	t.Setenv("GITHUB_REF_NAME", "42/merge")
+	t.Setenv("GITHUB_HEAD_REF", "greeting")
+	t.Setenv("GITHUB_BASE_REF", "main")
+
+	pipeline := drone.Pipeline{
+		Build: drone.Build{Number: 7},
+	}
+	assert.NoError(t, Fill(&pipeline))
+
+	assert.Equal(t, drone.EventPullRequest, pipeline.Build.Event)
+	assert.Equal(t, drone.ActionSynchronized, pipeline.Build.Action)
+	assert.Equal(t, 7, pipeline.Build.Number)
+	assert.Equal(t, 42, pipeline.Build.PullRequest)
+	assert.Equal(t, "greeting", pipeline.Build.SourceBranch)
+	assert.Equal(t, "main", pipeline.Build.TargetBranch)
+	assert.Equal(t, "main", pipeline.Build.Branch)
+	assert.Equal(t, "9c2d4f6a8b0c1d2e3f4a5b6c7d8e9f0a1f3b5e7a", pipeline.Commit.SHA)
+	assert.Equal(t, "https://github.com/octocat/hello-world/pull/42", pipeline.Commit.Link)
+	assert.Equal(t, drone.Message{Title: "Add greeting", Body: "Says hello to the world."}, pipeline.Commit.Message)
+	assert.Equal(t, "hubot", pipeline.Commit.Author.Username)
+	assert.True(t, pipeline.Repo.Private)
+
+	assert.Equal(t, drone.PullRequest{
+		Number:       42,
+		Title:        "Add greeting",
+		Link:         "https://github.com/octocat/hello-world/pull/42",
+		SourceRepo:   "hubot/hello-world",
+		TargetRepo:   "octocat/hello-world",
+		SourceBranch: "greeting",
+		TargetBranch: "main",
+		HeadSHA:      "9c2d4f6a8b0c1d2e3f4a5b6c7d8e9f0a1f3b5e7a",
+		BaseSHA:      "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	}, pipeline.PullRequest)
+	assert.True(t, pipeline.PullRequest.IsFromFork())
+}
+
+// Test loading the action inputs as plugin settings
+func TestLoadGitHubInputs(t *testing.T) {
+	t.Setenv("INPUT_DRY-RUN", "true")
+	t.Setenv("INPUT_REPO", "octocat/input")
+	t.Setenv("PLUGIN_REPO", "octocat/plugin")
+	t.Setenv("PLUGIN_DRY_RUN", "")
+	os.Unsetenv("PLUGIN_DRY_RUN")
+
+	assert.NoError(t, LoadGitHubInputs())
+	assert.Equal(t, "true", os.Getenv("PLUGIN_DRY_RUN"))
+	assert.Equal(t, "octocat/plugin", os.Getenv("PLUGIN_REPO"))
+}
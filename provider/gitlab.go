@@ -0,0 +1,169 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package provider
+
+import (
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// GitLab fills the pipeline from the GitLab CI environment.
+//
+// Merge requests are mapped to pull_request events and the pipeline source
+// to the closest Drone event.
+type GitLab struct{}
+
+// Kind returns drone.HostGitLab.
+func (GitLab) Kind() drone.HostKind {
+	return drone.HostGitLab
+}
+
+// Detect checks if the plugin is running within GitLab CI.
+func (GitLab) Detect() bool {
+	return env("GITLAB_CI") == "true"
+}
+
+// Version returns the version of GitLab.
+func (GitLab) Version() string {
+	return env("CI_SERVER_VERSION")
+}
+
+// Fill sets the missing fields of the pipeline from the GitLab variables.
+func (GitLab) Fill(p *drone.Pipeline) error {
+	projectURL := env("CI_PROJECT_URL")
+	path := env("CI_PROJECT_PATH")
+	sha := env("CI_COMMIT_SHA")
+	mr := env("CI_MERGE_REQUEST_IID")
+	tag := env("CI_COMMIT_TAG")
+
+	fillString(&p.Repo.Slug, path)
+	fillString(&p.Repo.Owner, env("CI_PROJECT_NAMESPACE"))
+	fillString(&p.Repo.Name, env("CI_PROJECT_NAME"))
+	fillString(&p.Repo.SCM, "git")
+	fillString(&p.Repo.Link, projectURL)
+	fillString(&p.Repo.Branch, env("CI_DEFAULT_BRANCH"))
+	fillString(&p.Repo.HTTPURL, withoutCredentials(env("CI_REPOSITORY_URL")))
+	fillString(&p.Repo.Visibility, env("CI_PROJECT_VISIBILITY"))
+
+	if host := firstNonEmpty(env("CI_SERVER_SHELL_SSH_HOST"), env("CI_SERVER_HOST")); host != "" && path != "" {
+		fillString(&p.Repo.SSHURL, "git@"+host+":"+path+".git")
+	}
+
+	if visibility := env("CI_PROJECT_VISIBILITY"); visibility != "" && visibility != "public" {
+		p.Repo.Private = true
+	}
+
+	fillInt(&p.Build.Number, env("CI_PIPELINE_IID"))
+	fillString(&p.Build.Event, gitlabEvent(env("CI_PIPELINE_SOURCE"), tag))
+	fillString(&p.Build.Link, env("CI_PIPELINE_URL"))
+	fillTime(&p.Build.Created, env("CI_PIPELINE_CREATED_AT"))
+	fillString(&p.Build.DeployTo, env("CI_ENVIRONMENT_NAME"))
+	fillString(&p.Build.Tag, tag)
+
+	if mr != "" {
+		fillInt(&p.Build.PullRequest, mr)
+		fillString(&p.Build.SourceBranch, env("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"))
+		fillString(&p.Build.TargetBranch, env("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"))
+		fillString(&p.Build.Branch, p.Build.TargetBranch)
+		fillString(&p.Commit.SHA, env("CI_MERGE_REQUEST_SOURCE_BRANCH_SHA"))
+		fillString(&p.Commit.Ref, "refs/merge-requests/"+mr+"/head")
+
+		fillString(&p.PullRequest.Title, env("CI_MERGE_REQUEST_TITLE"))
+		fillString(&p.PullRequest.Link, joinNonEmpty(env("CI_MERGE_REQUEST_PROJECT_URL"), "-", "merge_requests", mr))
+		fillString(&p.PullRequest.SourceRepo, env("CI_MERGE_REQUEST_SOURCE_PROJECT_PATH"))
+		fillString(&p.PullRequest.TargetRepo, env("CI_MERGE_REQUEST_PROJECT_PATH"))
+		fillString(&p.PullRequest.HeadSHA, env("CI_MERGE_REQUEST_SOURCE_BRANCH_SHA"))
+		fillString(&p.PullRequest.BaseSHA, env("CI_MERGE_REQUEST_TARGET_BRANCH_SHA"), env("CI_MERGE_REQUEST_DIFF_BASE_SHA"))
+	} else {
+		fillString(&p.Build.Branch, env("CI_COMMIT_BRANCH"))
+	}
+
+	fillString(&p.Commit.SHA, sha)
+	fillString(&p.Commit.After, sha)
+	fillString(&p.Commit.Branch, p.Build.Branch)
+
+	if before := env("CI_COMMIT_BEFORE_SHA"); strings.Trim(before, "0") != "" {
+		fillString(&p.Commit.Before, before)
+	}
+
+	switch {
+	case tag != "":
+		fillString(&p.Commit.Ref, "refs/tags/"+tag)
+	case env("CI_COMMIT_BRANCH") != "":
+		fillString(&p.Commit.Ref, "refs/heads/"+env("CI_COMMIT_BRANCH"))
+	}
+
+	if projectURL != "" && p.Commit.SHA != "" {
+		fillString(&p.Commit.Link, projectURL+"/-/commit/"+p.Commit.SHA)
+	}
+
+	if message := env("CI_COMMIT_MESSAGE"); message != "" {
+		fillMessage(&p.Commit.Message, message)
+	} else if mr != "" {
+		fillMessage(&p.Commit.Message, env("CI_MERGE_REQUEST_TITLE")+"\n\n"+env("CI_MERGE_REQUEST_DESCRIPTION"))
+	}
+
+	if author, err := mail.ParseAddress(env("CI_COMMIT_AUTHOR")); err == nil {
+		fillString(&p.Commit.Author.Name, author.Name)
+		fillString(&p.Commit.Author.Email, author.Address)
+	}
+
+	fillString(&p.Commit.Author.Username, env("GITLAB_USER_LOGIN"))
+
+	fillString(&p.Stage.Name, env("CI_JOB_STAGE"))
+	fillString(&p.Stage.Machine, env("CI_RUNNER_DESCRIPTION"))
+
+	if goos, goarch, ok := strings.Cut(env("CI_RUNNER_EXECUTABLE_ARCH"), "/"); ok {
+		fillString(&p.Stage.OS, goos)
+		fillString(&p.Stage.Arch, goarch)
+	}
+
+	fillString(&p.Step.Name, env("CI_JOB_NAME"))
+
+	fillString(&p.System.Proto, env("CI_SERVER_PROTOCOL"))
+	fillString(&p.System.Host, env("CI_SERVER_HOST"))
+	fillString(&p.System.Version, env("CI_SERVER_VERSION"))
+
+	fillString(&p.Workspace.Dir, env("CI_PROJECT_DIR"))
+	fillString(&p.Workspace.Base, env("CI_BUILDS_DIR"))
+
+	return nil
+}
+
+// gitlabEvent converts the pipeline source to a Drone event.
+func gitlabEvent(source, tag string) drone.Event {
+	switch source {
+	case "push":
+		if tag != "" {
+			return drone.EventTag
+		}
+
+		return drone.EventPush
+	case "merge_request_event", "external_pull_request_event":
+		return drone.EventPullRequest
+	case "schedule":
+		return drone.EventCron
+	case "":
+		return ""
+	}
+
+	return drone.EventCustom
+}
+
+// withoutCredentials removes the credentials from the url.
+func withoutCredentials(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+
+	u.User = nil
+
+	return u.String()
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// HarnessExtension contains the Harness specific identifiers of the
+// execution.
+type HarnessExtension struct {
+	// AccountID of the Harness account.
+	AccountID string `json:"account_id" yaml:"account_id"`
+
+	// OrgID of the organization.
+	OrgID string `json:"org_id" yaml:"org_id"`
+
+	// ProjectID of the project.
+	ProjectID string `json:"project_id" yaml:"project_id"`
+
+	// PipelineID of the pipeline.
+	PipelineID string `json:"pipeline_id" yaml:"pipeline_id"`
+
+	// ExecutionID of the pipeline execution.
+	ExecutionID string `json:"execution_id" yaml:"execution_id"`
+
+	// StageID of the stage.
+	StageID string `json:"stage_id" yaml:"stage_id"`
+
+	// StepID of the step.
+	StepID string `json:"step_id" yaml:"step_id"`
+
+	// TriggerType that started the execution, such as WEBHOOK, SCHEDULED
+	// or MANUAL.
+	TriggerType string `json:"trigger_type" yaml:"trigger_type"`
+}
+
+// HarnessFromEnv creates a HarnessExtension from the environment.
+func HarnessFromEnv() HarnessExtension {
+	return HarnessExtension{
+		AccountID:   env("HARNESS_ACCOUNT_ID"),
+		OrgID:       env("HARNESS_ORG_ID"),
+		ProjectID:   env("HARNESS_PROJECT_ID"),
+		PipelineID:  env("HARNESS_PIPELINE_ID"),
+		ExecutionID: env("HARNESS_EXECUTION_ID"),
+		StageID:     env("HARNESS_STAGE_ID"),
+		StepID:      env("HARNESS_STEP_ID"),
+		TriggerType: env("HARNESS_TRIGGER_TYPE"),
+	}
+}
+
+// Harness fills the pipeline from the Harness CI environment.
+//
+// Harness provides most DRONE_* variables, so only missing fields are filled
+// from the HARNESS_* variables.
+type Harness struct{}
+
+// Kind returns drone.HostHarness.
+func (Harness) Kind() drone.HostKind {
+	return drone.HostHarness
+}
+
+// Detect checks if the plugin is running within Harness CI.
+func (Harness) Detect() bool {
+	return env("HARNESS_ACCOUNT_ID") != "" || env("HARNESS_BUILD_ID") != ""
+}
+
+// Version returns the version of Harness CI, which is not provided.
+func (Harness) Version() string {
+	return ""
+}
+
+// Fill sets the missing fields of the pipeline from the Harness variables.
+func (Harness) Fill(p *drone.Pipeline) error {
+	ext := HarnessFromEnv()
+
+	fillInt(&p.Build.Number, env("HARNESS_BUILD_ID"))
+	fillString(&p.Build.Branch, env("HARNESS_BRANCH"))
+	fillString(&p.Build.Tag, env("HARNESS_TAG"))
+	fillInt(&p.Build.PullRequest, env("HARNESS_PR_NUMBER"))
+	fillString(&p.Build.SourceBranch, env("HARNESS_SOURCE_BRANCH"))
+	fillString(&p.Build.TargetBranch, env("HARNESS_TARGET_BRANCH"))
+	fillString(&p.Build.Link, env("HARNESS_EXECUTION_URL"))
+	fillString(&p.Build.Event, harnessEvent(p.Build, ext.TriggerType))
+
+	fillString(&p.Commit.SHA, env("HARNESS_COMMIT_SHA"))
+	fillString(&p.Commit.Branch, p.Build.TargetBranch, p.Build.Branch)
+
+	fillString(&p.Stage.Name, ext.StageID)
+	fillString(&p.Step.Name, ext.StepID)
+
+	fillString(&p.Workspace.Dir, env("HARNESS_WORKSPACE"))
+
+	return nil
+}
+
+// harnessEvent derives the event from the trigger type and build.
+func harnessEvent(build drone.Build, triggerType string) drone.Event {
+	switch {
+	case strings.EqualFold(triggerType, "SCHEDULED"):
+		return drone.EventCron
+	case build.PullRequest != 0:
+		return drone.EventPullRequest
+	case build.Tag != "":
+		return drone.EventTag
+	case strings.EqualFold(triggerType, "MANUAL"):
+		return drone.EventCustom
+	case triggerType != "" || build.Branch != "":
+		return drone.EventPush
+	}
+
+	return ""
+}
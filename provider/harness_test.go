@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test filling the pipeline from the Harness environment
+func TestHarness(t *testing.T) {
+	dronetest.ClearEnv(t)
+
+	t.Setenv("HARNESS_ACCOUNT_ID", "account")
+	t.Setenv("HARNESS_ORG_ID", "default")
+	t.Setenv("HARNESS_PROJECT_ID", "plugins")
+	t.Setenv("HARNESS_PIPELINE_ID", "publish")
+	t.Setenv("HARNESS_EXECUTION_ID", "abc123")
+	t.Setenv("HARNESS_STAGE_ID", "build")
+	t.Setenv("HARNESS_STEP_ID", "docker")
+	t.Setenv("HARNESS_BUILD_ID", "17")
+	t.Setenv("HARNESS_TRIGGER_TYPE", "WEBHOOK")
+	t.Setenv("HARNESS_PR_NUMBER", "5")
+	t.Setenv("HARNESS_SOURCE_BRANCH", "feature")
+	t.Setenv("HARNESS_TARGET_BRANCH", "main")
+
+	assert.Equal(t, Harness{}, Detected())
+
+	pipeline := drone.Pipeline{
+		Step: drone.Step{Name: "publish"},
+	}
+	assert.NoError(t, Fill(&pipeline))
+
+	assert.Equal(t, 17, pipeline.Build.Number)
+	assert.Equal(t, drone.EventPullRequest, pipeline.Build.Event)
+	assert.Equal(t, 5, pipeline.Build.PullRequest)
+	assert.Equal(t, "main", pipeline.Commit.Branch)
+	assert.Equal(t, "build", pipeline.Stage.Name)
+	assert.Equal(t, "publish", pipeline.Step.Name)
+
+	assert.Equal(t, HarnessExtension{
+		AccountID:   "account",
+		OrgID:       "default",
+		ProjectID:   "plugins",
+		PipelineID:  "publish",
+		ExecutionID: "abc123",
+		StageID:     "build",
+		StepID:      "docker",
+		TriggerType: "WEBHOOK",
+	}, HarnessFromEnv())
+}
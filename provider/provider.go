@@ -0,0 +1,218 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package provider fills a drone.Pipeline from the environment of the host
+// CI.
+//
+// Plugins read the pipeline from the DRONE_* environment variables. When a
+// plugin runs natively within another CI system some or all of these are
+// missing, so the provider for the host CI fills the remaining fields from
+// the variables of that system.
+package provider
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// Provider fills a drone.Pipeline from the environment of a host CI.
+type Provider interface {
+	// Kind of the host CI.
+	Kind() drone.HostKind
+
+	// Detect checks if the plugin is running within the host CI.
+	Detect() bool
+
+	// Version of the host CI, empty if unknown.
+	Version() string
+
+	// Fill sets the fields of the pipeline provided by the host CI.
+	Fill(p *drone.Pipeline) error
+}
+
+// providers in the order they are detected.
+//
+// Harness and Woodpecker provide DRONE_* variables for compatibility so
+// they are detected before Drone. The local git repository is the fallback
+// when no CI system is detected.
+var providers = []Provider{
+	Harness{},
+	Woodpecker{},
+	Drone{},
+	GitLab{},
+	GitHub{},
+	Git{},
+}
+
+var (
+	// hostEnv are the environment variables used to detect the host CI.
+	hostEnv = []string{
+		"CI",
+		"DRONE",
+		"GITHUB_ACTIONS",
+		"GITLAB_CI",
+	}
+
+	// hostEnvPrefixes are the prefixes of the environment variables the
+	// providers read.
+	hostEnvPrefixes = []string{
+		"CI_",
+		"DRONE_",
+		"GITHUB_",
+		"GITLAB_",
+		"HARNESS_",
+		"RUNNER_",
+	}
+)
+
+// IsHostEnv checks if the environment variable is set by one of the host CI
+// systems and read by the providers.
+func IsHostEnv(env string) bool {
+	for _, name := range hostEnv {
+		if env == name {
+			return true
+		}
+	}
+
+	for _, prefix := range hostEnvPrefixes {
+		if strings.HasPrefix(env, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Detected returns the provider for the host CI, or nil if the plugin is
+// not running within a supported host CI.
+func Detected() Provider {
+	for _, provider := range providers {
+		if provider.Detect() {
+			return provider
+		}
+	}
+
+	return nil
+}
+
+// Detect returns the kind and version of the host CI.
+//
+// HostLocal is returned if the plugin is not running within a supported
+// host CI.
+func Detect() (drone.HostKind, string) {
+	provider := Detected()
+	if provider == nil {
+		return drone.HostLocal, ""
+	}
+
+	return provider.Kind(), provider.Version()
+}
+
+// Fill fills the pipeline using the provider for the host CI.
+//
+// The kind of the host CI is set on the drone.System of the pipeline, along
+// with the version if missing.
+func Fill(p *drone.Pipeline) error {
+	provider := Detected()
+	if provider == nil {
+		p.System.Kind = drone.HostLocal
+		return nil
+	}
+
+	p.System.Kind = provider.Kind()
+	fillString(&p.System.Version, provider.Version())
+
+	if err := provider.Fill(p); err != nil {
+		return err
+	}
+
+	p.FillPullRequest()
+
+	return nil
+}
+
+// fillString sets dst to the first non-empty value if dst is empty.
+func fillString[T ~string](dst *T, values ...T) {
+	if *dst != "" {
+		return
+	}
+
+	for _, value := range values {
+		if value != "" {
+			*dst = value
+			return
+		}
+	}
+}
+
+// fillInt sets dst to the first value that is a non-zero number if dst is
+// zero.
+func fillInt(dst *int, values ...string) {
+	if *dst != 0 {
+		return
+	}
+
+	for _, value := range values {
+		if n, err := strconv.Atoi(value); err == nil && n != 0 {
+			*dst = n
+			return
+		}
+	}
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty[T ~string](values ...T) T {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// fillTime sets dst to the RFC 3339 time if dst is zero.
+func fillTime(dst *time.Time, value string) {
+	if !dst.IsZero() {
+		return
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		*dst = t
+	}
+}
+
+// setString sets dst to the first non-empty value.
+func setString[T ~string](dst *T, values ...T) {
+	for _, value := range values {
+		if value != "" {
+			*dst = value
+			return
+		}
+	}
+}
+
+// setInt sets dst to value if it is a non-zero number.
+func setInt(dst *int, value string) {
+	if n, err := strconv.Atoi(value); err == nil && n != 0 {
+		*dst = n
+	}
+}
+
+// setTime sets dst to value if it is a non-zero unix timestamp.
+func setTime(dst *time.Time, value string) {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && n != 0 {
+		*dst = time.Unix(n, 0)
+	}
+}
+
+// env returns the environment variable.
+func env(name string) string {
+	return os.Getenv(name)
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test matching the environment variables of the host CI
+func TestIsHostEnv(t *testing.T) {
+	for _, env := range []string{"CI", "DRONE", "DRONE_REPO", "GITHUB_ACTIONS", "GITHUB_SHA", "RUNNER_OS", "CI_COMMIT_SHA", "GITLAB_CI", "HARNESS_BUILD_ID"} {
+		assert.True(t, IsHostEnv(env), env)
+	}
+
+	for _, env := range []string{"CIRCLE", "PLUGIN_TOKEN", "INPUT_TOKEN", "HOME", "DRONEX"} {
+		assert.False(t, IsHostEnv(env), env)
+	}
+}
+
+// Test detecting the host CI
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		env     map[string]string
+		kind    drone.HostKind
+		version string
+	}{
+		{
+			env:  map[string]string{},
+			kind: drone.HostLocal,
+		},
+		{
+			env:     map[string]string{"DRONE": "true", "DRONE_SYSTEM_VERSION": "2.20.0"},
+			kind:    drone.HostDrone,
+			version: "2.20.0",
+		},
+		{
+			env:  map[string]string{"DRONE": "true", "HARNESS_BUILD_ID": "3"},
+			kind: drone.HostHarness,
+		},
+		{
+			env:     map[string]string{"DRONE": "true", "CI": "woodpecker", "CI_SYSTEM_VERSION": "2.7.0"},
+			kind:    drone.HostWoodpecker,
+			version: "2.7.0",
+		},
+		{
+			env:     map[string]string{"CI": "true", "GITLAB_CI": "true", "CI_SERVER_VERSION": "17.0.0"},
+			kind:    drone.HostGitLab,
+			version: "17.0.0",
+		},
+		{
+			env:  map[string]string{"CI": "true", "GITHUB_ACTIONS": "true"},
+			kind: drone.HostGitHub,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.kind), func(t *testing.T) {
+			dronetest.ClearEnv(t)
+
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			kind, version := Detect()
+			assert.Equal(t, test.kind, kind)
+			assert.Equal(t, test.version, version)
+
+			pipeline := drone.Pipeline{}
+			assert.NoError(t, Fill(&pipeline))
+			assert.Equal(t, test.kind, pipeline.System.Kind)
+		})
+	}
+}
+
+// Test the capabilities of the host CI
+func TestCapabilities(t *testing.T) {
+	assert.Equal(t, 78, drone.System{Kind: drone.HostDrone}.Capabilities().SkipExitCode)
+	assert.True(t, drone.System{Kind: drone.HostHarness}.Capabilities().Outputs)
+	assert.Equal(t, drone.Capabilities{}, drone.System{Kind: drone.HostLocal}.Capabilities())
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// Woodpecker fills the pipeline from the Woodpecker CI environment.
+//
+// Woodpecker still provides some DRONE_* variables for compatibility, but
+// they are deprecated and may be stale, so any CI_* variable that is set
+// takes precedence over the value read from the DRONE_* variables. Fields
+// without a CI_* variable keep their DRONE_* value.
+type Woodpecker struct{}
+
+// Kind returns drone.HostWoodpecker.
+func (Woodpecker) Kind() drone.HostKind {
+	return drone.HostWoodpecker
+}
+
+// Detect checks if the plugin is running within Woodpecker CI.
+func (Woodpecker) Detect() bool {
+	return env("CI") == "woodpecker" || env("CI_SYSTEM_NAME") == "woodpecker"
+}
+
+// Version returns the version of Woodpecker CI.
+func (Woodpecker) Version() string {
+	return env("CI_SYSTEM_VERSION")
+}
+
+// Fill sets the fields of the pipeline from the Woodpecker variables.
+func (Woodpecker) Fill(p *drone.Pipeline) error {
+	event, action := woodpeckerEvent(env("CI_PIPELINE_EVENT"))
+
+	setInt(&p.Build.Number, env("CI_PIPELINE_NUMBER"))
+	setInt(&p.Build.Parent, env("CI_PIPELINE_PARENT"))
+	setString(&p.Build.Event, event)
+	setString(&p.Build.Action, action)
+	setString(&p.Build.Status, drone.Status(env("CI_PIPELINE_STATUS")))
+	setString(&p.Build.Link, env("CI_PIPELINE_URL"), env("CI_PIPELINE_LINK"))
+	setTime(&p.Build.Created, env("CI_PIPELINE_CREATED"))
+	setTime(&p.Build.Started, env("CI_PIPELINE_STARTED"))
+	setTime(&p.Build.Finished, env("CI_PIPELINE_FINISHED"))
+	setString(&p.Build.DeployTo, env("CI_PIPELINE_DEPLOY_TARGET"), env("CI_COMMIT_DEPLOY_TARGET"))
+	setString(&p.Build.Branch, env("CI_COMMIT_BRANCH"))
+	setString(&p.Build.Tag, env("CI_COMMIT_TAG"))
+	setInt(&p.Build.PullRequest, env("CI_COMMIT_PULL_REQUEST"))
+	setInt(&p.Build.PreviousNumber, env("CI_PREV_PIPELINE_NUMBER"))
+	setString(&p.Build.PreviousStatus, drone.Status(env("CI_PREV_PIPELINE_STATUS")))
+	setString(&p.Build.PreviousSHA, env("CI_PREV_COMMIT_SHA"))
+	setString(&p.Build.SourceBranch, env("CI_COMMIT_SOURCE_BRANCH"))
+	setString(&p.Build.TargetBranch, env("CI_COMMIT_TARGET_BRANCH"))
+
+	setString(&p.Repo.Slug, env("CI_REPO"))
+	setString(&p.Repo.Owner, env("CI_REPO_OWNER"))
+	setString(&p.Repo.Name, env("CI_REPO_NAME"))
+	setString(&p.Repo.SCM, env("CI_REPO_SCM"))
+	setString(&p.Repo.Link, env("CI_REPO_URL"), env("CI_REPO_LINK"))
+	setString(&p.Repo.Branch, env("CI_REPO_DEFAULT_BRANCH"))
+	setString(&p.Repo.HTTPURL, env("CI_REPO_CLONE_URL"))
+	setString(&p.Repo.SSHURL, env("CI_REPO_CLONE_SSH_URL"))
+
+	if private, err := strconv.ParseBool(env("CI_REPO_PRIVATE")); err == nil {
+		p.Repo.Private = private
+	}
+
+	setString(&p.Commit.SHA, env("CI_COMMIT_SHA"))
+	setString(&p.Commit.After, env("CI_COMMIT_SHA"))
+	setString(&p.Commit.Ref, env("CI_COMMIT_REF"))
+	setString(&p.Commit.Branch, env("CI_COMMIT_TARGET_BRANCH"), env("CI_COMMIT_BRANCH"))
+	setString(&p.Commit.Link, env("CI_COMMIT_URL"), env("CI_COMMIT_LINK"))
+	setString(&p.Commit.Author.Username, env("CI_COMMIT_AUTHOR"))
+	setString(&p.Commit.Author.Email, env("CI_COMMIT_AUTHOR_EMAIL"))
+	setString(&p.Commit.Author.Avatar, env("CI_COMMIT_AUTHOR_AVATAR"))
+
+	if message := env("CI_COMMIT_MESSAGE"); message != "" {
+		p.Commit.Message = drone.ParseMessage(message)
+	}
+
+	setString(&p.Stage.Name, env("CI_WORKFLOW_NAME"))
+	setInt(&p.Stage.Number, env("CI_WORKFLOW_NUMBER"))
+
+	if goos, goarch, ok := strings.Cut(env("CI_SYSTEM_PLATFORM"), "/"); ok {
+		setString(&p.Stage.OS, goos)
+		setString(&p.Stage.Arch, goarch)
+	}
+
+	setString(&p.Step.Name, env("CI_STEP_NAME"))
+	setInt(&p.Step.Number, env("CI_STEP_NUMBER"))
+
+	setString(&p.System.Host, env("CI_SYSTEM_HOST"))
+	setString(&p.System.Version, env("CI_SYSTEM_VERSION"))
+
+	if proto, _, ok := strings.Cut(env("CI_SYSTEM_URL"), "://"); ok {
+		setString(&p.System.Proto, proto)
+	}
+
+	setString(&p.Workspace.Dir, env("CI_WORKSPACE"))
+
+	return nil
+}
+
+// woodpeckerEvent converts the Woodpecker event to a Drone event and action.
+func woodpeckerEvent(event string) (drone.Event, drone.Action) {
+	switch event {
+	case "pull_request_closed":
+		return drone.EventPullRequest, drone.ActionClosed
+	case "release":
+		return drone.EventTag, ""
+	case "deployment", "deploy":
+		return drone.EventPromote, ""
+	case "manual":
+		return drone.EventCustom, ""
+	}
+
+	return drone.Event(event), ""
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test filling the pipeline from the Woodpecker environment
+func TestWoodpecker(t *testing.T) {
+	dronetest.ClearEnv(t)
+
+	t.Setenv("CI", "woodpecker")
+	t.Setenv("CI_REPO", "octocat/hello-world")
+	t.Setenv("CI_REPO_OWNER", "octocat")
+	t.Setenv("CI_REPO_NAME", "hello-world")
+	t.Setenv("CI_REPO_PRIVATE", "true")
+	t.Setenv("CI_COMMIT_SHA", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	t.Setenv("CI_COMMIT_BRANCH", "main")
+	t.Setenv("CI_COMMIT_MESSAGE", "Update README\n\nAdd usage section")
+	t.Setenv("CI_COMMIT_AUTHOR", "octocat")
+	t.Setenv("CI_PIPELINE_NUMBER", "8")
+	t.Setenv("CI_PIPELINE_EVENT", "pull_request_closed")
+	t.Setenv("CI_PIPELINE_STARTED", "1577872860")
+	t.Setenv("CI_STEP_NAME", "publish")
+	t.Setenv("CI_SYSTEM_PLATFORM", "linux/arm64")
+	t.Setenv("CI_SYSTEM_URL", "https://ci.example.com")
+
+	assert.Equal(t, Woodpecker{}, Detected())
+
+	// Values read from the deprecated DRONE_* variables
+	pipeline := drone.Pipeline{
+		Build: drone.Build{Number: 7, Link: "https://ci.example.com/7"},
+		Step:  drone.Step{Name: "stale"},
+	}
+	assert.NoError(t, Fill(&pipeline))
+
+	assert.Equal(t, 8, pipeline.Build.Number)
+	assert.Equal(t, "https://ci.example.com/7", pipeline.Build.Link)
+	assert.Equal(t, drone.EventPullRequest, pipeline.Build.Event)
+	assert.Equal(t, drone.ActionClosed, pipeline.Build.Action)
+	assert.Equal(t, time.Unix(1577872860, 0), pipeline.Build.Started)
+	assert.Equal(t, "octocat/hello-world", pipeline.Repo.Slug)
+	assert.True(t, pipeline.Repo.Private)
+	assert.Equal(t, "Update README", pipeline.Commit.Message.Title)
+	assert.Equal(t, "publish", pipeline.Step.Name)
+	assert.Equal(t, "arm64", pipeline.Stage.Arch)
+	assert.Equal(t, "https", pipeline.System.Proto)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package secret
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus.Hook that masks registered secrets in the message and
+// fields of every log entry.
+type Hook struct{}
+
+// NewHook creates a new Hook.
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+// Levels implements the logrus.Hook interface.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	entry.Message = Mask(entry.Message)
+
+	for k, v := range entry.Data {
+		if value, ok := v.(string); ok {
+			entry.Data[k] = Mask(value)
+			continue
+		}
+
+		// Other values, like slices of header values, are masked using
+		// their formatted form.
+		if formatted := fmt.Sprint(v); Mask(formatted) != formatted {
+			entry.Data[k] = Mask(formatted)
+		}
+	}
+
+	return nil
+}
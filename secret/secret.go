@@ -0,0 +1,225 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package secret provides masking of secret values in plugin output.
+//
+// Values are registered once and then masked with `***` wherever they appear
+// in log entries or subprocess output, including their common base64 and URL
+// encoded forms.
+package secret
+
+import (
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Placeholder is the value secrets are replaced with.
+const Placeholder = "***"
+
+// minLength is the minimum length of a secret that is registered. Masking
+// shorter values would garble unrelated output.
+const minLength = 4
+
+// minLineLength is the minimum length of a line of a multiline secret that is
+// registered on its own.
+const minLineLength = 8
+
+var (
+	mu       sync.RWMutex
+	values   = map[string]struct{}{}
+	replacer *strings.Replacer
+)
+
+// Register adds the values to the set of secrets being masked.
+//
+// Encoded forms of each value are registered as well. For multiline values
+// every line is also registered so output split on newlines is masked. Values
+// shorter than four characters, and lines that are short, only punctuation or
+// PEM armor, are skipped as masking them would mask unrelated output.
+func Register(secrets ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, s := range secrets {
+		if len(s) < minLength {
+			continue
+		}
+
+		for _, v := range variants(s) {
+			values[v] = struct{}{}
+		}
+
+		if strings.Contains(s, "\n") {
+			for _, line := range strings.Split(s, "\n") {
+				line = strings.TrimSpace(line)
+				if !isSecretLine(line) {
+					continue
+				}
+
+				for _, v := range variants(line) {
+					values[v] = struct{}{}
+				}
+			}
+		}
+	}
+
+	replacer = nil
+}
+
+// RegisterURL registers the password embedded in the URL.
+//
+// Values that are not URLs or do not contain credentials are ignored.
+func RegisterURL(raw string) {
+	if !strings.Contains(raw, "@") {
+		return
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return
+	}
+
+	if password, ok := u.User.Password(); ok {
+		Register(password)
+	}
+}
+
+// Reset removes all registered secrets.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	values = map[string]struct{}{}
+	replacer = nil
+}
+
+// Snapshot saves the registered secrets, returning a function to restore
+// them.
+//
+// This is used by tests to undo the secrets registered while running a
+// plugin.
+func Snapshot() (restore func()) {
+	mu.RLock()
+	saved := make(map[string]struct{}, len(values))
+	for v := range values {
+		saved[v] = struct{}{}
+	}
+	mu.RUnlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		values = saved
+		replacer = nil
+	}
+}
+
+// Mask replaces all registered secrets within s with the Placeholder.
+func Mask(s string) string {
+	if s == "" {
+		return s
+	}
+
+	r := currentReplacer()
+
+	if r == nil {
+		return s
+	}
+
+	return r.Replace(s)
+}
+
+// currentReplacer returns the replacer for the registered secrets, building
+// it if the set of secrets has changed.
+func currentReplacer() *strings.Replacer {
+	mu.RLock()
+	r := replacer
+	empty := len(values) == 0
+	mu.RUnlock()
+
+	if r != nil || empty {
+		return r
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if replacer != nil {
+		return replacer
+	}
+
+	secrets := make([]string, 0, len(values))
+	for v := range values {
+		secrets = append(secrets, v)
+	}
+
+	// Longer values are matched first so a secret containing another
+	// secret is masked entirely.
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+
+		return secrets[i] < secrets[j]
+	})
+
+	pairs := make([]string, 0, len(secrets)*2)
+	for _, v := range secrets {
+		pairs = append(pairs, v, Placeholder)
+	}
+
+	replacer = strings.NewReplacer(pairs...)
+
+	return replacer
+}
+
+// isSecretLine checks if the line of a multiline secret is distinctive
+// enough to be masked on its own.
+func isSecretLine(line string) bool {
+	if len(line) < minLineLength || strings.HasPrefix(line, "-----") {
+		return false
+	}
+
+	return strings.IndexFunc(line, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) != -1
+}
+
+// variants returns the value along with its encoded forms.
+func variants(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	b := []byte(s)
+	candidates := []string{
+		s,
+		base64.StdEncoding.EncodeToString(b),
+		base64.RawStdEncoding.EncodeToString(b),
+		base64.URLEncoding.EncodeToString(b),
+		base64.RawURLEncoding.EncodeToString(b),
+		url.QueryEscape(s),
+		url.PathEscape(s),
+	}
+
+	seen := map[string]struct{}{}
+	result := make([]string, 0, len(candidates))
+
+	for _, c := range candidates {
+		if _, ok := seen[c]; ok {
+			continue
+		}
+
+		seen[c] = struct{}{}
+		result = append(result, c)
+	}
+
+	return result
+}
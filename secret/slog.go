@@ -0,0 +1,83 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package secret
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler that masks registered secrets in the message
+// and attributes of every record before passing it to the wrapped handler.
+type SlogHandler struct {
+	handler slog.Handler
+}
+
+// NewSlogHandler creates a new SlogHandler wrapping h.
+func NewSlogHandler(h slog.Handler) *SlogHandler {
+	return &SlogHandler{
+		handler: h,
+	}
+}
+
+// Enabled implements the slog.Handler interface.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements the slog.Handler interface.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	masked := slog.NewRecord(r.Time, r.Level, Mask(r.Message), r.PC)
+
+	r.Attrs(func(a slog.Attr) bool {
+		masked.AddAttrs(maskAttr(a))
+		return true
+	})
+
+	return h.handler.Handle(ctx, masked)
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	masked := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		masked = append(masked, maskAttr(a))
+	}
+
+	return NewSlogHandler(h.handler.WithAttrs(masked))
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return NewSlogHandler(h.handler.WithGroup(name))
+}
+
+// maskAttr masks the registered secrets within the attribute value.
+func maskAttr(a slog.Attr) slog.Attr {
+	value := a.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Mask(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		masked := make([]any, 0, len(group))
+
+		for _, g := range group {
+			masked = append(masked, maskAttr(g))
+		}
+
+		return slog.Group(a.Key, masked...)
+	default:
+		// Other values, like slices of header values, are masked using
+		// their formatted form.
+		if formatted := value.String(); Mask(formatted) != formatted {
+			return slog.String(a.Key, Mask(formatted))
+		}
+	}
+
+	return slog.Attr{Key: a.Key, Value: value}
+}
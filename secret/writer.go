@@ -0,0 +1,72 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package secret
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Writer is an io.Writer that masks registered secrets before writing to
+// the underlying writer.
+//
+// Output is buffered until a newline is written so secrets split across
+// writes are still masked. Close must be called to flush any remaining
+// output.
+type Writer struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+// NewWriter creates a new Writer wrapping w.
+//
+// This is intended to wrap the stdout and stderr of subprocesses.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w: w,
+	}
+}
+
+// Write implements the io.Writer interface.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+
+		line := w.buf.Next(i + 1)
+		if _, err := io.WriteString(w.w, Mask(string(line))); err != nil {
+			return len(p), err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close flushes any buffered output to the underlying writer.
+//
+// The underlying writer is not closed.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	_, err := io.WriteString(w.w, Mask(w.buf.String()))
+	w.buf.Reset()
+
+	return err
+}
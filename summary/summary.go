@@ -0,0 +1,251 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package summary provides a markdown step summary shared across plugins.
+//
+// A Summary accumulates markdown sections, tables and links while the plugin
+// executes and writes them to the summary location of the host CI once it is
+// finished.
+//
+//	s := summary.New()
+//	s.Heading(2, "Published images")
+//	s.Table([]string{"Image", "Digest"}, [][]string{{"octocat/hello-world:latest", digest}})
+//
+//	if err := s.Write(); err != nil {
+//		return err
+//	}
+package summary
+
+import (
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/drone-plugins/drone-plugin-lib/card"
+	"github.com/drone-plugins/drone-plugin-lib/harness"
+)
+
+const (
+	// GitHubEnv is the environment variable holding the GitHub Actions step
+	// summary file.
+	GitHubEnv = "GITHUB_STEP_SUMMARY"
+
+	// OutputKey is the output the summary is written to when using the
+	// DRONE_OUTPUT file.
+	OutputKey = "SUMMARY"
+
+	// OutputBase64Key is the output the base64 encoded summary is written to
+	// when the DRONE_OUTPUT file cannot hold multiline values.
+	OutputBase64Key = "SUMMARY_BASE64"
+)
+
+// CardTemplate is the adaptive card template used to render the summary.
+//
+// Adaptive cards only support a subset of markdown so headings and tables
+// are rendered as plain text.
+const CardTemplate = `{
+  "type": "AdaptiveCard",
+  "$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+  "version": "1.5",
+  "body": [
+    {
+      "type": "TextBlock",
+      "text": "${markdown}",
+      "wrap": true
+    }
+  ]
+}`
+
+// Summary accumulates markdown for the step summary.
+type Summary struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+// New creates an empty Summary.
+func New() *Summary {
+	return &Summary{}
+}
+
+// Heading adds a heading of the level, between 1 and 6.
+func (s *Summary) Heading(level int, text string) *Summary {
+	if level < 1 {
+		level = 1
+	} else if level > 6 {
+		level = 6
+	}
+
+	return s.block(strings.Repeat("#", level) + " " + text)
+}
+
+// Text adds a paragraph of text.
+func (s *Summary) Text(text string) *Summary {
+	return s.block(text)
+}
+
+// List adds a bulleted list.
+func (s *Summary) List(items ...string) *Summary {
+	if len(items) == 0 {
+		return s
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, "- "+item)
+	}
+
+	return s.block(strings.Join(lines, "\n"))
+}
+
+// Table adds a table with the header and rows.
+func (s *Summary) Table(header []string, rows [][]string) *Summary {
+	if len(header) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+
+		for i := range header {
+			cell := ""
+			if i < len(cells) {
+				cell = escapeCell(cells[i])
+			}
+
+			b.WriteString(" " + cell + " |")
+		}
+
+		b.WriteString("\n")
+	}
+
+	writeRow(header)
+
+	b.WriteString("|")
+	for range header {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+
+	for _, row := range rows {
+		writeRow(row)
+	}
+
+	return s.block(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// Code adds a fenced code block in the language.
+func (s *Summary) Code(language, code string) *Summary {
+	return s.block("```" + language + "\n" + strings.TrimSuffix(code, "\n") + "\n```")
+}
+
+// Markdown adds raw markdown.
+func (s *Summary) Markdown(markdown string) *Summary {
+	return s.block(markdown)
+}
+
+// String returns the markdown of the summary.
+func (s *Summary) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.String()
+}
+
+// Empty checks if nothing was added to the summary.
+func (s *Summary) Empty() bool {
+	return s.String() == ""
+}
+
+// Write writes the summary to every summary location of the host CI.
+//
+// The summary is appended to the GITHUB_STEP_SUMMARY file when running in
+// GitHub Actions. For Drone and Harness the summary is written as a card
+// when DRONE_CARD_PATH is set, otherwise it is written to the SUMMARY output
+// when DRONE_OUTPUT is set. Only .env output files support multiline values,
+// so for other output files the summary is written base64 encoded to the
+// SUMMARY_BASE64 output instead. Writing a card replaces any other card
+// written by the plugin. Nothing is written for an empty summary.
+func (s *Summary) Write() error {
+	markdown := s.String()
+	if markdown == "" {
+		return nil
+	}
+
+	var errs []error
+
+	if path := os.Getenv(GitHubEnv); path != "" {
+		errs = append(errs, appendFile(path, markdown))
+	}
+
+	if os.Getenv(card.PathEnv) != "" {
+		errs = append(errs, card.Write(CardTemplate, map[string]string{
+			"markdown": markdown,
+		}))
+	} else if path := os.Getenv(harness.DroneOutputFile); path != "" {
+		if strings.EqualFold(filepath.Ext(path), ".env") {
+			errs = append(errs, harness.SetOutput(OutputKey, markdown))
+		} else {
+			errs = append(errs, harness.SetOutput(OutputBase64Key, base64.StdEncoding.EncodeToString([]byte(markdown))))
+		}
+	}
+
+	return stderrors.Join(errs...)
+}
+
+// WriteFile writes the summary to the file at path.
+func (s *Summary) WriteFile(path string) error {
+	if err := os.WriteFile(path, []byte(s.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write summary to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Link formats a markdown link.
+func Link(title, url string) string {
+	return "[" + title + "](" + url + ")"
+}
+
+// block adds a block of markdown separated by a blank line.
+func (s *Summary) block(markdown string) *Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.buf.Len() != 0 {
+		s.buf.WriteString("\n")
+	}
+
+	s.buf.WriteString(markdown)
+	s.buf.WriteString("\n")
+
+	return s
+}
+
+// appendFile appends the markdown to the file at path.
+func appendFile(path, markdown string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open summary file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(markdown); err != nil {
+		return fmt.Errorf("failed to write summary file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// escapeCell escapes the table cell content.
+func escapeCell(cell string) string {
+	cell = strings.ReplaceAll(cell, "|", "\\|")
+	return strings.ReplaceAll(cell, "\n", "<br>")
+}
@@ -0,0 +1,80 @@
+package summary
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/harness"
+	v3 "github.com/harness/godotenv/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test building the markdown of a summary
+func TestSummary(t *testing.T) {
+	s := New().
+		Heading(2, "Published").
+		Text("Pushed to "+Link("Docker Hub", "https://hub.docker.com")).
+		Table([]string{"Image", "Digest"}, [][]string{{"octocat/hello-world:latest", "sha256:1234"}, {"a|b"}}).
+		List("one", "two")
+
+	expected := "## Published\n" +
+		"\n" +
+		"Pushed to [Docker Hub](https://hub.docker.com)\n" +
+		"\n" +
+		"| Image | Digest |\n" +
+		"| --- | --- |\n" +
+		"| octocat/hello-world:latest | sha256:1234 |\n" +
+		"| a\\|b |  |\n" +
+		"\n" +
+		"- one\n" +
+		"- two\n"
+
+	assert.Equal(t, expected, s.String())
+}
+
+// Test writing the summary to the host CI locations
+func TestSummaryWrite(t *testing.T) {
+	dir := t.TempDir()
+	github := filepath.Join(dir, "summary.md")
+	output := filepath.Join(dir, "output.env")
+
+	t.Setenv(GitHubEnv, github)
+	t.Setenv("DRONE_CARD_PATH", "")
+	t.Setenv("DRONE_OUTPUT", output)
+
+	s := New().Heading(1, "Done")
+	assert.NoError(t, s.Write())
+
+	b, err := os.ReadFile(github)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Done\n", string(b))
+
+	data, err := v3.Read(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Done", data[OutputKey])
+}
+
+// Test multiline summaries are encoded for .out output files
+func TestSummaryWriteOut(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "output.out")
+
+	t.Setenv(GitHubEnv, "")
+	t.Setenv("DRONE_CARD_PATH", "")
+	t.Setenv("DRONE_OUTPUT", output)
+
+	s := New().Heading(1, "Done").List("one", "two")
+	assert.NoError(t, s.Write())
+
+	lines, err := harness.ReadLines(output)
+	assert.NoError(t, err)
+	assert.Len(t, lines, 1)
+
+	key, value := harness.ParseKeyValue(lines[0], ".out")
+	assert.Equal(t, OutputBase64Key, key)
+
+	markdown, err := base64.StdEncoding.DecodeString(value)
+	assert.NoError(t, err)
+	assert.Equal(t, s.String(), string(markdown))
+}
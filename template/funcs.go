@@ -0,0 +1,95 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package template
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// now returns the current time and is replaced in tests.
+var now = time.Now
+
+// Funcs returns the functions available to templates.
+//
+// The functions take the piped value as their last argument so they can be
+// chained, for example {{ .commit.sha | truncate 8 | upper }}.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"truncate":     truncate,
+		"since":        since,
+		"datetime":     datetime,
+		"upper":        strings.ToUpper,
+		"lower":        strings.ToLower,
+		"trim":         strings.TrimSpace,
+		"regexReplace": regexReplace,
+		"title":        title,
+		"url":          joinURL,
+		"default":      defaultValue,
+	}
+}
+
+// truncate shortens s to at most n characters.
+func truncate(n int, s string) string {
+	runes := []rune(s)
+	if n < 0 || len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n])
+}
+
+// since returns the time elapsed since t rounded to the second.
+func since(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return now().Sub(t).Round(time.Second).String()
+}
+
+// datetime formats t using the Go time layout in UTC.
+func datetime(layout string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(layout)
+}
+
+// regexReplace replaces the matches of the pattern in s with replacement.
+func regexReplace(pattern, replacement, s string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	return re.ReplaceAllString(s, replacement), nil
+}
+
+// title returns the title of the commit message.
+func title(message string) string {
+	return drone.ParseMessage(message).Title
+}
+
+// joinURL joins the escaped path segments to the base URL.
+func joinURL(base string, segments ...string) (string, error) {
+	return url.JoinPath(base, segments...)
+}
+
+// defaultValue returns def if value is empty.
+func defaultValue(def, value string) string {
+	if value == "" {
+		return def
+	}
+
+	return value
+}
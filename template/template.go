@@ -0,0 +1,303 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+// Package template renders message templates against a drone.Pipeline.
+//
+// Templates use the text/template syntax with the pipeline exposed using the
+// JSON names of the model, so the build number is available as
+// {{ .build.number }} and the repository slug as {{ .repo.slug }}. Bare
+// references like {{build.number}} are rewritten to the dotted form.
+//
+//	t, err := template.Load(network, "Build {{ .build.number }} of {{ .repo.slug }} {{ .build.status }}")
+//	if err != nil {
+//		return err
+//	}
+//
+//	msg, err := t.Execute(pipeline)
+//
+// Only the functions of this package are available to templates, which have
+// no access to the environment, the file system or the network.
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// maxTemplateSize is the maximum size of a template loaded from a URL.
+const maxTemplateSize = 1 << 20
+
+// Template is a parsed message template.
+type Template struct {
+	name string
+	tmpl *template.Template
+}
+
+// Parse parses the template text.
+func Parse(name, text string) (*Template, error) {
+	text = rewritePaths(text)
+
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(Funcs()).
+		Parse(text)
+	if err != nil {
+		return nil, parseError(name, text, err)
+	}
+
+	return &Template{name: name, tmpl: tmpl}, nil
+}
+
+// ParseFile parses the template in the file at path.
+func ParseFile(path string) (*Template, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+
+	return Parse(path, string(b))
+}
+
+// ParseURL parses the template downloaded from the url using the client and
+// context of the network.
+func ParseURL(network drone.Network, url string) (*Template, error) {
+	client := network.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for template %s: %w", url, err)
+	}
+
+	if network.Context != nil {
+		req = req.WithContext(network.Context)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download template %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download template %s: %s", url, resp.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxTemplateSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", url, err)
+	}
+
+	if len(b) > maxTemplateSize {
+		return nil, fmt.Errorf("template %s exceeds %d bytes", url, maxTemplateSize)
+	}
+
+	return Parse(url, string(b))
+}
+
+// Load parses the template from the source.
+//
+// Sources starting with http:// or https:// are downloaded, sources starting
+// with file:// are read from the file system and any other source is parsed
+// as an inline template.
+func Load(network drone.Network, source string) (*Template, error) {
+	switch {
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		return ParseURL(network, source)
+	case strings.HasPrefix(source, "file://"):
+		return ParseFile(strings.TrimPrefix(source, "file://"))
+	default:
+		return Parse("inline", source)
+	}
+}
+
+// Execute renders the template against the pipeline.
+func (t *Template) Execute(p drone.Pipeline) (string, error) {
+	return t.ExecuteData(Data(p))
+}
+
+// ExecuteData renders the template against the data.
+func (t *Template) ExecuteData(data interface{}) (string, error) {
+	var buf bytes.Buffer
+
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", t.name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// Render parses the template text and renders it against the pipeline.
+func Render(text string, p drone.Pipeline) (string, error) {
+	t, err := Parse("inline", text)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Execute(p)
+}
+
+// Data converts the pipeline into the data templates are rendered against.
+//
+// Structs are converted to maps keyed by the JSON names of their fields.
+// Times are kept so they can be formatted and commit messages are converted
+// to the full message.
+func Data(p drone.Pipeline) map[string]interface{} {
+	return toData(reflect.ValueOf(p)).(map[string]interface{})
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	messageType = reflect.TypeOf(drone.Message{})
+)
+
+// toData converts the value into template data.
+func toData(v reflect.Value) interface{} {
+	switch {
+	case v.Type() == timeType:
+		return v.Interface()
+	case v.Type() == messageType:
+		return v.Interface().(drone.Message).String()
+	case v.Kind() == reflect.Struct:
+		data := map[string]interface{}{}
+
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			data[name] = toData(v.Field(i))
+		}
+
+		return data
+	case v.Kind() == reflect.String:
+		return v.String()
+	default:
+		return v.Interface()
+	}
+}
+
+// undefinedFunc matches the parse error of an undefined function.
+var undefinedFunc = regexp.MustCompile(`function "([^"]+)" not defined`)
+
+// barePath matches a reference like build.number without the leading dot.
+var barePath = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)+`)
+
+// parseError adds a hint when references to the pipeline are missing the
+// leading dot, such as {{build}} instead of {{ .build }}.
+func parseError(name, text string, err error) error {
+	if match := undefinedFunc.FindStringSubmatch(err.Error()); match != nil {
+		if _, ok := Data(drone.Pipeline{})[match[1]]; ok {
+			path := regexp.MustCompile(`\b` + regexp.QuoteMeta(match[1]) + `(\.[A-Za-z0-9_]+)*`).FindString(text)
+			return fmt.Errorf("failed to parse template %s: %w (use {{ .%s }} to reference the pipeline)", name, err, path)
+		}
+	}
+
+	return fmt.Errorf("failed to parse template %s: %w", name, err)
+}
+
+// rewritePaths adds the leading dot to bare references to the pipeline
+// within the actions of the template, so {{build.number}} is rendered the
+// same as {{ .build.number }}.
+func rewritePaths(text string) string {
+	keys := Data(drone.Pipeline{})
+
+	var b strings.Builder
+
+	for {
+		start := strings.Index(text, "{{")
+		if start == -1 {
+			break
+		}
+
+		end := strings.Index(text[start+2:], "}}")
+		if end == -1 {
+			break
+		}
+
+		end += start + 2
+
+		b.WriteString(text[:start+2])
+		b.WriteString(rewriteAction(text[start+2:end], keys))
+		text = text[end:]
+	}
+
+	b.WriteString(text)
+
+	return b.String()
+}
+
+// rewriteAction adds the leading dot to bare references to the pipeline
+// within the action, skipping comments and string literals.
+func rewriteAction(action string, keys map[string]interface{}) string {
+	if strings.HasPrefix(strings.TrimLeft(action, "- "), "/*") {
+		return action
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(action); i++ {
+		c := action[i]
+
+		switch {
+		case c == '"' || c == '`' || c == '\'':
+			end := closingQuote(action, i)
+			b.WriteString(action[i:end])
+			i = end - 1
+
+			continue
+		case i == 0 || strings.IndexByte(" \t\r\n(|", action[i-1]) != -1:
+			if path := barePath.FindString(action[i:]); path != "" {
+				key, _, _ := strings.Cut(path, ".")
+				if _, ok := keys[key]; ok {
+					b.WriteByte('.')
+				}
+
+				b.WriteString(path)
+				i += len(path) - 1
+
+				continue
+			}
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// closingQuote returns the index after the literal starting at start.
+func closingQuote(s string, start int) int {
+	quote := s[start]
+
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote != '`':
+			i++
+		case s[i] == quote:
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
@@ -0,0 +1,102 @@
+package template
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/stretchr/testify/assert"
+)
+
+var pipeline = drone.Pipeline{
+	Build: drone.Build{
+		Number:  42,
+		Event:   drone.EventPush,
+		Status:  drone.StatusSuccess,
+		Started: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	},
+	Repo: drone.Repo{
+		Slug: "octocat/hello-world",
+	},
+	System: drone.System{
+		Kind: drone.HostDrone,
+	},
+	Commit: drone.Commit{
+		SHA:     "1f3b5e7a9c2d4f6a8b0c1d2e3f4a5b6c7d8e9f0a",
+		Message: drone.Message{Title: "Fix the build", Body: "It was broken."},
+	},
+}
+
+// Test rendering the pipeline with helpers
+func TestRender(t *testing.T) {
+	now = func() time.Time { return pipeline.Build.Started.Add(90 * time.Second) }
+	defer func() { now = time.Now }()
+
+	tests := map[string]string{
+		"Build {{ .build.number }} of {{ .repo.slug }} {{ .build.status }}":  "Build 42 of octocat/hello-world success",
+		"{{ .commit.sha | truncate 8 | upper }}":                             "1F3B5E7A",
+		"{{ .commit.message | title }}":                                      "Fix the build",
+		"{{ since .build.started }}":                                         "1m30s",
+		`{{ .build.started | datetime "2006-01-02" }}`:                       "2026-01-02",
+		`{{ .repo.slug | regexReplace "/.*" "" }}`:                           "octocat",
+		`{{ url "https://example.com" .repo.slug "a b" }}`:                   "https://example.com/octocat/hello-world/a%20b",
+		`{{ .build.tag | default "latest" }}`:                                "latest",
+		"Build {{build.number}} of {{repo.slug}} {{build.status}}":           "Build 42 of octocat/hello-world success",
+		`{{- commit.sha | truncate 8 }} {{ "build.number" }}`:                "1f3b5e7a build.number",
+		"{{ .build.status | upper }}":                                        "SUCCESS",
+		`{{ .build.event | truncate 2 }} {{ .build.event | default "tag" }}`: "pu push",
+		`{{ .build.action | default "none" }}`:                               "none",
+		"{{ .system.kind | upper }}":                                         "DRONE",
+	}
+
+	for text, expected := range tests {
+		actual, err := Render(text, pipeline)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, actual, text)
+	}
+}
+
+// Test errors for missing fields and handlebars style references
+func TestRenderErrors(t *testing.T) {
+	_, err := Render("{{ .build.numbr }}", pipeline)
+	assert.Contains(t, err.Error(), `map has no entry for key "numbr"`)
+
+	_, err = Render("{{build}}", pipeline)
+	assert.Contains(t, err.Error(), "use {{ .build }}")
+
+	_, err = Render("{{build.number}} {{ .build.numbr }}", pipeline)
+	assert.Contains(t, err.Error(), `map has no entry for key "numbr"`)
+
+	_, err = Render(`{{ env "HOME" }}`, pipeline)
+	assert.Contains(t, err.Error(), `function "env" not defined`)
+}
+
+// Test loading templates from files and URLs
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message.tmpl")
+	assert.NoError(t, os.WriteFile(path, []byte("{{ .repo.slug }}"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("#{{ .build.number }}"))
+	}))
+	defer server.Close()
+
+	tests := map[string]string{
+		"file://" + path: "octocat/hello-world",
+		server.URL:       "#42",
+		"inline":         "inline",
+	}
+
+	for source, expected := range tests {
+		tmpl, err := Load(drone.Network{}, source)
+		assert.NoError(t, err, source)
+
+		actual, err := tmpl.Execute(pipeline)
+		assert.NoError(t, err, source)
+		assert.Equal(t, expected, actual, source)
+	}
+}
@@ -11,18 +11,24 @@ import (
 	"net/http/httptrace"
 	"net/textproto"
 
+	"github.com/drone-plugins/drone-plugin-lib/drone"
 	"github.com/sirupsen/logrus"
 )
 
 // HTTP uses httptrace to log all network activity for HTTP requests.
+//
+// Activity is logged to the logger carried by the context, falling back to
+// the logrus standard logger.
 func HTTP(ctx context.Context) context.Context {
+	logger := drone.LoggerFromContext(ctx)
+
 	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
 		GetConn: func(hostPort string) {
-			logrus.WithField("host-port", hostPort).Trace("ClientTrace.GetConn")
+			logger.WithField("host-port", hostPort).Trace("ClientTrace.GetConn")
 		},
 
 		GotConn: func(connInfo httptrace.GotConnInfo) {
-			logrus.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"local-address":  connInfo.Conn.LocalAddr(),
 				"remote-address": connInfo.Conn.RemoteAddr(),
 				"reused":         connInfo.Reused,
@@ -32,19 +38,19 @@ func HTTP(ctx context.Context) context.Context {
 		},
 
 		PutIdleConn: func(err error) {
-			logrus.WithField("error", err).Trace("ClientTrace.GoConn")
+			logger.WithField("error", err).Trace("ClientTrace.GoConn")
 		},
 
 		GotFirstResponseByte: func() {
-			logrus.Trace("ClientTrace.GotFirstResponseByte")
+			logger.Trace("ClientTrace.GotFirstResponseByte")
 		},
 
 		Got100Continue: func() {
-			logrus.Trace("ClientTrace.Got100Continue")
+			logger.Trace("ClientTrace.Got100Continue")
 		},
 
 		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
-			logrus.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"code":   code,
 				"header": header,
 			}).Trace("ClientTrace.Got1xxxResponse")
@@ -52,11 +58,11 @@ func HTTP(ctx context.Context) context.Context {
 		},
 
 		DNSStart: func(dnsInfo httptrace.DNSStartInfo) {
-			logrus.WithField("host", dnsInfo.Host).Trace("ClientTrace.DNSStart")
+			logger.WithField("host", dnsInfo.Host).Trace("ClientTrace.DNSStart")
 		},
 
 		DNSDone: func(dnsInfo httptrace.DNSDoneInfo) {
-			logrus.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"addresses": dnsInfo.Addrs,
 				"error":     dnsInfo.Err,
 				"coalesced": dnsInfo.Coalesced,
@@ -64,14 +70,14 @@ func HTTP(ctx context.Context) context.Context {
 		},
 
 		ConnectStart: func(network, addr string) {
-			logrus.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"network": network,
 				"address": addr,
 			}).Trace("ClientTrace.ConnectStart")
 		},
 
 		ConnectDone: func(network, addr string, err error) {
-			logrus.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"network": network,
 				"address": addr,
 				"error":   err,
@@ -79,11 +85,11 @@ func HTTP(ctx context.Context) context.Context {
 		},
 
 		TLSHandshakeStart: func() {
-			logrus.Trace("ClientTrace.TLSHandshakeStart")
+			logger.Trace("ClientTrace.TLSHandshakeStart")
 		},
 
 		TLSHandshakeDone: func(cs tls.ConnectionState, err error) {
-			logrus.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"version":             cs.Version,
 				"handshake-complete":  cs.HandshakeComplete,
 				"did-resume":          cs.DidResume,
@@ -95,22 +101,22 @@ func HTTP(ctx context.Context) context.Context {
 		},
 
 		WroteHeaderField: func(key string, value []string) {
-			logrus.WithFields(logrus.Fields{
+			logger.WithFields(logrus.Fields{
 				"key":    key,
 				"values": value,
 			}).Trace("ClientTrace.WroteHeaderField")
 		},
 
 		WroteHeaders: func() {
-			logrus.Trace("ClientTrace.WroteHeaders")
+			logger.Trace("ClientTrace.WroteHeaders")
 		},
 
 		Wait100Continue: func() {
-			logrus.Trace("ClientTrace.Wait100Continue")
+			logger.Trace("ClientTrace.Wait100Continue")
 		},
 
 		WroteRequest: func(reqInfo httptrace.WroteRequestInfo) {
-			logrus.WithField("error", reqInfo.Err).Trace("ClientTrace.WroteRequest")
+			logger.WithField("error", reqInfo.Err).Trace("ClientTrace.WroteRequest")
 		},
 	})
 }
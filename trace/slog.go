@@ -0,0 +1,131 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package trace
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"net/http/httptrace"
+	"net/textproto"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+)
+
+// LevelTrace is the slog level used for tracing network activity.
+//
+// slog has no trace level so this is one step below slog.LevelDebug.
+const LevelTrace = slog.LevelDebug - 4
+
+// SlogHTTP uses httptrace to log all network activity for HTTP requests.
+//
+// Activity is logged at LevelTrace to the slog logger carried by the
+// context, falling back to the default slog logger.
+func SlogHTTP(ctx context.Context) context.Context {
+	logger := drone.SlogFromContext(ctx)
+
+	log := func(msg string, args ...any) {
+		logger.Log(ctx, LevelTrace, msg, args...)
+	}
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn: func(hostPort string) {
+			log("ClientTrace.GetConn", "host-port", hostPort)
+		},
+
+		GotConn: func(connInfo httptrace.GotConnInfo) {
+			log("ClientTrace.GoConn",
+				"local-address", connInfo.Conn.LocalAddr(),
+				"remote-address", connInfo.Conn.RemoteAddr(),
+				"reused", connInfo.Reused,
+				"was-idle", connInfo.WasIdle,
+				"idle-time", connInfo.IdleTime,
+			)
+		},
+
+		PutIdleConn: func(err error) {
+			log("ClientTrace.GoConn", "error", err)
+		},
+
+		GotFirstResponseByte: func() {
+			log("ClientTrace.GotFirstResponseByte")
+		},
+
+		Got100Continue: func() {
+			log("ClientTrace.Got100Continue")
+		},
+
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			log("ClientTrace.Got1xxxResponse",
+				"code", code,
+				"header", header,
+			)
+			return nil
+		},
+
+		DNSStart: func(dnsInfo httptrace.DNSStartInfo) {
+			log("ClientTrace.DNSStart", "host", dnsInfo.Host)
+		},
+
+		DNSDone: func(dnsInfo httptrace.DNSDoneInfo) {
+			log("ClientTrace.DNSDone",
+				"addresses", dnsInfo.Addrs,
+				"error", dnsInfo.Err,
+				"coalesced", dnsInfo.Coalesced,
+			)
+		},
+
+		ConnectStart: func(network, addr string) {
+			log("ClientTrace.ConnectStart",
+				"network", network,
+				"address", addr,
+			)
+		},
+
+		ConnectDone: func(network, addr string, err error) {
+			log("ClientTrace.ConnectDone",
+				"network", network,
+				"address", addr,
+				"error", err,
+			)
+		},
+
+		TLSHandshakeStart: func() {
+			log("ClientTrace.TLSHandshakeStart")
+		},
+
+		TLSHandshakeDone: func(cs tls.ConnectionState, err error) {
+			log("ClientTrace.TLSHandshakeDone",
+				"version", cs.Version,
+				"handshake-complete", cs.HandshakeComplete,
+				"did-resume", cs.DidResume,
+				"cipher-suite", cs.CipherSuite,
+				"negotiated-protocol", cs.NegotiatedProtocol,
+				"server-name", cs.ServerName,
+				"error", err,
+			)
+		},
+
+		WroteHeaderField: func(key string, value []string) {
+			log("ClientTrace.WroteHeaderField",
+				"key", key,
+				"values", value,
+			)
+		},
+
+		WroteHeaders: func() {
+			log("ClientTrace.WroteHeaders")
+		},
+
+		Wait100Continue: func() {
+			log("ClientTrace.Wait100Continue")
+		},
+
+		WroteRequest: func(reqInfo httptrace.WroteRequestInfo) {
+			log("ClientTrace.WroteRequest", "error", reqInfo.Err)
+		},
+	})
+}
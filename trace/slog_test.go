@@ -0,0 +1,61 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test requests are traced through the slog logger of the context
+func TestSlogHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: LevelTrace}))
+
+	ctx := SlogHTTP(drone.WithSlog(context.Background(), logger))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	res, err := server.Client().Do(req)
+	assert.NoError(t, err)
+	res.Body.Close()
+
+	out := buf.String()
+	assert.Contains(t, out, "level=DEBUG-4")
+	assert.Contains(t, out, "msg=ClientTrace.GetConn")
+	assert.Contains(t, out, "msg=ClientTrace.WroteRequest")
+	assert.Contains(t, out, "msg=ClientTrace.GotFirstResponseByte")
+}
+
+// Test nothing is traced above the trace level
+func TestSlogHTTPLevel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	ctx := SlogHTTP(drone.WithSlog(context.Background(), logger))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	res, err := server.Client().Do(req)
+	assert.NoError(t, err)
+	res.Body.Close()
+
+	assert.Empty(t, buf.String())
+}
@@ -105,29 +105,71 @@ func buildFlags() []cli.Flag {
 			Usage:   "build failed steps",
 			EnvVars: []string{"DRONE_FAILED_STEPS"},
 		},
+		&cli.IntFlag{
+			Name:    "build.previous-number",
+			Usage:   "previous build number",
+			EnvVars: []string{"DRONE_PREV_BUILD_NUMBER"},
+		},
+		&cli.StringFlag{
+			Name:    "build.previous-status",
+			Usage:   "previous build status",
+			EnvVars: []string{"DRONE_PREV_BUILD_STATUS"},
+		},
+		&cli.StringFlag{
+			Name:    "build.previous-sha",
+			Usage:   "previous build commit sha",
+			EnvVars: []string{"DRONE_PREV_COMMIT_SHA"},
+		},
 	}
 }
 
 // buildFromContext creates a drone.Build from the cli.Context.
 func buildFromContext(ctx *cli.Context) drone.Build {
 	return drone.Build{
-		Branch:       ctx.String("build.branch"),
-		PullRequest:  ctx.Int("build.pull-request"),
-		Tag:          ctx.String("build.tag"),
-		SourceBranch: ctx.String("build.source-branch"),
-		TargetBranch: ctx.String("build.target-branch"),
-		Number:       ctx.Int("build.number"),
-		Parent:       ctx.Int("build.parent"),
-		Event:        ctx.String("build.event"),
-		Action:       ctx.String("build.action"),
-		Status:       ctx.String("build.status"),
-		Link:         ctx.String("build.link"),
-		Created:      time.Unix(ctx.Int64("build.created"), 0),
-		Started:      time.Unix(ctx.Int64("build.started"), 0),
-		Finished:     time.Unix(ctx.Int64("build.finished"), 0),
-		DeployTo:     ctx.String("build.deploy-to"),
-		DeployID:     ctx.Int("build.deploy-id"),
-		FailedStages: ctx.StringSlice("build.failed-stages"),
-		FailedSteps:  ctx.StringSlice("build.failed-steps"),
+		Branch:         ctx.String("build.branch"),
+		PullRequest:    ctx.Int("build.pull-request"),
+		Tag:            ctx.String("build.tag"),
+		SourceBranch:   ctx.String("build.source-branch"),
+		TargetBranch:   ctx.String("build.target-branch"),
+		Number:         ctx.Int("build.number"),
+		Parent:         ctx.Int("build.parent"),
+		Event:          parseFromContext(ctx, "build.event", drone.ParseEvent),
+		Action:         parseFromContext(ctx, "build.action", drone.ParseAction),
+		Status:         parseFromContext(ctx, "build.status", drone.ParseStatus),
+		Link:           ctx.String("build.link"),
+		Created:        timeFromContext(ctx, "build.created"),
+		Started:        timeFromContext(ctx, "build.started"),
+		Finished:       timeFromContext(ctx, "build.finished"),
+		DeployTo:       ctx.String("build.deploy-to"),
+		DeployID:       ctx.Int("build.deploy-id"),
+		FailedStages:   ctx.StringSlice("build.failed-stages"),
+		FailedSteps:    ctx.StringSlice("build.failed-steps"),
+		PreviousNumber: ctx.Int("build.previous-number"),
+		PreviousStatus: parseFromContext(ctx, "build.previous-status", drone.ParseStatus),
+		PreviousSHA:    ctx.String("build.previous-sha"),
+	}
+}
+
+// timeFromContext reads the unix timestamp of the flag, returning the zero
+// time.Time if it is unset.
+func timeFromContext(ctx *cli.Context, name string) time.Time {
+	if unix := ctx.Int64(name); unix != 0 {
+		return time.Unix(unix, 0)
 	}
+
+	return time.Time{}
+}
+
+// parseFromContext parses the value of the flag, logging a warning if the
+// value is unknown.
+//
+// Unknown values are still returned so plugins can handle values added by
+// newer versions of Drone.
+func parseFromContext[T any](ctx *cli.Context, name string, parse func(string) (T, error)) T {
+	value, err := parse(ctx.String(name))
+	if err != nil {
+		drone.LoggerFromContext(ctx.Context).WithField("flag", name).Warning(err)
+	}
+
+	return value
 }
@@ -0,0 +1,109 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	v3 "github.com/harness/godotenv/v3"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// envFileFlag is the name of the env file flag.
+	envFileFlag = "env-file"
+
+	// envFileEnv is the environment variable for the env files.
+	envFileEnv = "PLUGIN_ENV_FILE"
+)
+
+// envFileFlags has the cli.Flags for the developer mode env files.
+func envFileFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    envFileFlag,
+			Usage:   ".env files to load before parsing flags",
+			EnvVars: []string{envFileEnv},
+		},
+	}
+}
+
+// LoadEnvFiles loads the .env files requested by the env-file flag or the
+// PLUGIN_ENV_FILE environment variable into the environment.
+//
+// This is an opt-in developer mode for running plugins outside of a
+// pipeline. Environment variables are read when the flags are parsed so this
+// needs to be called before running the cli.App.
+//
+//	func main() {
+//		if err := urfave.LoadEnvFiles(os.Args); err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		app.Run(os.Args)
+//	}
+//
+// Variables already present in the environment take precedence over the
+// files, and earlier files take precedence over later ones.
+func LoadEnvFiles(args []string) error {
+	files := EnvFiles(args)
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	for _, file := range files {
+		if err := v3.Load(file); err != nil {
+			return fmt.Errorf("failed to load env file %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// EnvFiles returns the .env files requested by the env-file flag within the
+// arguments or by the PLUGIN_ENV_FILE environment variable.
+func EnvFiles(args []string) []string {
+	var files []string
+
+	add := func(value string) {
+		for _, file := range strings.Split(value, ",") {
+			if file = strings.TrimSpace(file); file != "" {
+				files = append(files, file)
+			}
+		}
+	}
+
+	if len(args) > 0 {
+		args = args[1:]
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			break
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(name, envFileFlag+"="); ok {
+			add(value)
+		} else if name == envFileFlag && i+1 < len(args) {
+			add(args[i+1])
+			i++
+		}
+	}
+
+	add(os.Getenv(envFileEnv))
+
+	return files
+}
@@ -0,0 +1,33 @@
+package urfave
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test loading .env files with the real environment taking precedence
+func TestLoadEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.env")
+	second := filepath.Join(dir, "second.env")
+
+	assert.NoError(t, os.WriteFile(first, []byte("DRONE_REPO=octocat/first\nPLUGIN_TEST_A=first\n"), 0600))
+	assert.NoError(t, os.WriteFile(second, []byte("PLUGIN_TEST_A=second\nPLUGIN_TEST_B=second\n"), 0600))
+
+	t.Setenv("DRONE_REPO", "octocat/real")
+	t.Setenv("PLUGIN_TEST_A", "")
+	t.Setenv("PLUGIN_TEST_B", "")
+	os.Unsetenv("PLUGIN_TEST_A")
+	os.Unsetenv("PLUGIN_TEST_B")
+
+	args := []string{"plugin", "--env-file", first, "--env-file=" + second, "--", "--env-file", "ignored"}
+	assert.Equal(t, []string{first, second}, EnvFiles(args))
+
+	assert.NoError(t, LoadEnvFiles(args))
+	assert.Equal(t, "octocat/real", os.Getenv("DRONE_REPO"))
+	assert.Equal(t, "first", os.Getenv("PLUGIN_TEST_A"))
+	assert.Equal(t, "second", os.Getenv("PLUGIN_TEST_B"))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/expand"
+	"github.com/urfave/cli/v2"
+)
+
+// settingPrefix is the prefix of the environment variables for settings.
+const settingPrefix = "PLUGIN_"
+
+// expandFlags has the cli.Flags for expanding settings.
+func expandFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "expand-settings",
+			Usage:   "expand ${VAR} references within settings",
+			EnvVars: []string{"PLUGIN_EXPAND_SETTINGS"},
+		},
+	}
+}
+
+// ExpandFromContext expands shell-style variable references within the
+// string settings when requested by the expand-settings flag.
+//
+// Settings are the string and string slice flags read from PLUGIN_*
+// environment variables. References are resolved against the environment of
+// the pipeline. Secret flags are never expanded. Nothing happens if the
+// expand-settings flag is not set.
+func ExpandFromContext(ctx *cli.Context) error {
+	if !ctx.Bool("expand-settings") {
+		return nil
+	}
+
+	lookup := expand.Environ(PipelineFromContext(ctx))
+
+	for _, flag := range contextFlags(ctx) {
+		names := flag.Names()
+		if len(names) == 0 || IsSecret(names[0]) || !isSetting(flag) {
+			continue
+		}
+
+		name := names[0]
+
+		switch value := ctx.Value(name).(type) {
+		case string:
+			expanded, err := expand.Expand(value, lookup)
+			if err != nil {
+				return fmt.Errorf("failed to expand %s: %w", name, err)
+			}
+
+			if expanded == value {
+				continue
+			}
+
+			if err := ctx.Set(name, expanded); err != nil {
+				return fmt.Errorf("failed to set %s: %w", name, err)
+			}
+		case cli.StringSlice:
+			values := ctx.StringSlice(name)
+			expanded := make([]string, 0, len(values))
+			changed := false
+
+			for _, value := range values {
+				v, err := expand.Expand(value, lookup)
+				if err != nil {
+					return fmt.Errorf("failed to expand %s: %w", name, err)
+				}
+
+				expanded = append(expanded, v)
+				changed = changed || v != value
+			}
+
+			if !changed {
+				continue
+			}
+
+			// Setting a string slice appends unless the serialized form
+			// is used.
+			if err := ctx.Set(name, cli.NewStringSlice(expanded...).Serialize()); err != nil {
+				return fmt.Errorf("failed to set %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isSetting checks if the flag is read from a PLUGIN_* environment variable.
+func isSetting(flag cli.Flag) bool {
+	for _, env := range flagEnvVars(flag) {
+		if strings.HasPrefix(env, settingPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package urfave
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// Test expanding the string settings
+func TestExpandFromContext(t *testing.T) {
+	var (
+		repo     string
+		tags     []string
+		password string
+	)
+
+	app := &cli.App{
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:    "repo",
+				EnvVars: []string{"PLUGIN_REPO"},
+			},
+			&cli.StringSliceFlag{
+				Name:    "tags",
+				EnvVars: []string{"PLUGIN_TAGS"},
+			},
+			Secret(&cli.StringFlag{
+				Name:    "password",
+				EnvVars: []string{"PLUGIN_PASSWORD"},
+			}),
+		}, Flags()...),
+		Action: func(ctx *cli.Context) error {
+			if err := ExpandFromContext(ctx); err != nil {
+				return err
+			}
+
+			repo = ctx.String("repo")
+			tags = ctx.StringSlice("tags")
+			password = ctx.String("password")
+
+			return nil
+		},
+	}
+
+	t.Setenv("DRONE_REPO", "octocat/hello-world")
+	t.Setenv("DRONE_TAG", "v1.2.3")
+	t.Setenv("DRONE_COMMIT_SHA", "1f3b5e7a9c2d4f6a")
+	t.Setenv("PLUGIN_REPO", "${DRONE_REPO}")
+	t.Setenv("PLUGIN_TAGS", "latest,${DRONE_TAG##v}-${DRONE_COMMIT_SHA:0:8}")
+	t.Setenv("PLUGIN_PASSWORD", "pa$word")
+	t.Setenv("PLUGIN_EXPAND_SETTINGS", "true")
+
+	assert.NoError(t, app.Run([]string{"plugin"}))
+	assert.Equal(t, "octocat/hello-world", repo)
+	assert.Equal(t, []string{"latest", "1.2.3-1f3b5e7a"}, tags)
+	assert.Equal(t, "pa$word", password)
+}
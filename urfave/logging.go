@@ -6,10 +6,26 @@
 package urfave
 
 import (
+	"context"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	// LogFormatText is the human readable text log format.
+	LogFormatText = "text"
+
+	// LogFormatJSON is the JSON log format.
+	LogFormatJSON = "json"
+
+	// LogFormatLogfmt is the logfmt key/value log format.
+	LogFormatLogfmt = "logfmt"
+)
+
 // loggingFlags has the cli.Flags for logging config.
 func loggingFlags() []cli.Flag {
 	return []cli.Flag{
@@ -18,16 +34,174 @@ func loggingFlags() []cli.Flag {
 			Usage:   "log level",
 			EnvVars: []string{"PLUGIN_LOG_LEVEL"},
 		},
+		&cli.StringFlag{
+			Name:    "log-format",
+			Usage:   "log format (text, json, logfmt)",
+			Value:   LogFormatText,
+			EnvVars: []string{"PLUGIN_LOG_FORMAT"},
+		},
+		&cli.BoolFlag{
+			Name:    "log-timestamp",
+			Usage:   "log timestamps",
+			EnvVars: []string{"PLUGIN_LOG_TIMESTAMP"},
+		},
 	}
 }
 
-// LoggingFromContext sets the logrus logging level.
+// LoggingFromContext sets the logrus logging level, format and the standard
+// fields attached to every entry.
+//
+// Values of flags marked with Secret, and credentials embedded in URLs, are
+// masked in all log entries.
+//
+// This configures the logrus standard logger. Use LoggerFromContext to get a
+// logger instance that leaves the global logrus state untouched.
 func LoggingFromContext(ctx *cli.Context) {
-	lvl, err := logrus.ParseLevel(ctx.String("log-level"))
+	configureLogger(ctx, logrus.StandardLogger())
+}
+
+// LoggerFromContext creates a logrus.Logger from the cli.Context.
+//
+// The logger is configured the same as LoggingFromContext but does not modify
+// the global logrus state. It is also attached to ctx.Context so
+// NetworkFromContext and the trace package use it.
+//
+// If ctx.Context already carries a logger, passed in through cli.App
+// RunContext, that logger is configured and returned instead.
+func LoggerFromContext(ctx *cli.Context) *logrus.Logger {
+	if ctx.Context == nil {
+		ctx.Context = context.Background()
+	}
+
+	logger := logrus.New()
+	if drone.HasLogger(ctx.Context) {
+		logger = drone.LoggerFromContext(ctx.Context)
+	}
+
+	configureLogger(ctx, logger)
+
+	ctx.Context = drone.WithLogger(ctx.Context, logger)
+
+	return logger
+}
+
+// configureLogger sets the level, format and hooks of the logger.
+//
+// Hooks added by a previous call are replaced so configuring a logger again
+// does not fire them twice.
+func configureLogger(ctx *cli.Context, logger *logrus.Logger) {
+	secretsFromContext(ctx)
+
+	logger.SetLevel(logLevelFromContext(ctx, logger))
+	logger.SetFormatter(logFormatterFromContext(ctx, logger))
+
+	hooks := logrus.LevelHooks{}
+	for level, existing := range logger.ReplaceHooks(logrus.LevelHooks{}) {
+		for _, hook := range existing {
+			switch hook.(type) {
+			case *fieldsHook, *secret.Hook:
+				continue
+			}
+
+			hooks[level] = append(hooks[level], hook)
+		}
+	}
+
+	logger.ReplaceHooks(hooks)
+	logger.AddHook(&fieldsHook{
+		fields: logFieldsFromContext(ctx),
+	})
+	logger.AddHook(secret.NewHook())
+}
+
+// logLevelFromContext parses the log level, warning when it is invalid.
+func logLevelFromContext(ctx *cli.Context, logger *logrus.Logger) logrus.Level {
+	level := ctx.String("log-level")
+
+	if level == "" {
+		return logrus.InfoLevel
+	}
+
+	lvl, err := logrus.ParseLevel(level)
 
 	if err != nil {
-		lvl = logrus.InfoLevel
+		logger.WithField("log-level", level).Warning("invalid log level, using info")
+		return logrus.InfoLevel
+	}
+
+	return lvl
+}
+
+// logFormatterFromContext creates the logrus.Formatter for the log format.
+func logFormatterFromContext(ctx *cli.Context, logger *logrus.Logger) logrus.Formatter {
+	timestamp := ctx.Bool("log-timestamp")
+
+	switch format := ctx.String("log-format"); format {
+	case LogFormatJSON:
+		return &logrus.JSONFormatter{
+			DisableTimestamp: !timestamp,
+			TimestampFormat:  time.RFC3339,
+		}
+	case LogFormatLogfmt:
+		return &logrus.TextFormatter{
+			DisableColors:    true,
+			DisableTimestamp: !timestamp,
+			FullTimestamp:    true,
+			TimestampFormat:  time.RFC3339,
+		}
+	default:
+		if format != LogFormatText && format != "" {
+			logger.WithField("log-format", format).Warning("invalid log format, using text")
+		}
+
+		return &logrus.TextFormatter{
+			DisableTimestamp: !timestamp,
+			FullTimestamp:    true,
+			TimestampFormat:  time.RFC3339,
+		}
+	}
+}
+
+// logFieldsFromContext creates the standard log fields from the pipeline.
+func logFieldsFromContext(ctx *cli.Context) logrus.Fields {
+	fields := logrus.Fields{}
+
+	if ctx.App != nil && ctx.App.Name != "" {
+		fields["plugin"] = ctx.App.Name
+	}
+
+	if slug := ctx.String("repo.slug"); slug != "" {
+		fields["repo"] = slug
+	}
+
+	if number := ctx.Int("build.number"); number != 0 {
+		fields["build"] = number
+	}
+
+	if name := ctx.String("step.name"); name != "" {
+		fields["step"] = name
+	}
+
+	return fields
+}
+
+// fieldsHook attaches a fixed set of fields to every log entry.
+type fieldsHook struct {
+	fields logrus.Fields
+}
+
+// Levels implements the logrus.Hook interface.
+func (h *fieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (h *fieldsHook) Fire(entry *logrus.Entry) error {
+	for k, v := range h.fields {
+		if _, ok := entry.Data[k]; !ok {
+			entry.Data[k] = v
+		}
 	}
 
-	logrus.SetLevel(lvl)
+	return nil
 }
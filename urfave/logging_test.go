@@ -0,0 +1,91 @@
+package urfave
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// Test that a logger instance is configured without touching the global logger
+func TestLoggerFromContext(t *testing.T) {
+	level := logrus.GetLevel()
+
+	var buf bytes.Buffer
+
+	app := &cli.App{
+		Name:  "test-plugin",
+		Flags: Flags(),
+		Action: func(ctx *cli.Context) error {
+			logger := LoggerFromContext(ctx)
+			logger.SetOutput(&buf)
+
+			assert.Same(t, logger, drone.LoggerFromContext(ctx.Context))
+			assert.Same(t, logger, NetworkFromContext(ctx).Logger)
+
+			logger.Debug("message")
+
+			return nil
+		},
+	}
+
+	err := app.Run([]string{
+		"test-plugin",
+		"--log-level", "debug",
+		"--log-format", "json",
+		"--repo.slug", "octocat/hello-world",
+		"--build.number", "42",
+		"--step.name", "publish",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, level, logrus.GetLevel())
+
+	entry := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "message", entry["msg"])
+	assert.Equal(t, "test-plugin", entry["plugin"])
+	assert.Equal(t, "octocat/hello-world", entry["repo"])
+	assert.Equal(t, float64(42), entry["build"])
+	assert.Equal(t, "publish", entry["step"])
+	assert.NotContains(t, entry, "time")
+}
+
+// Test configuring a logger again does not add its hooks twice
+func TestConfigureLoggerTwice(t *testing.T) {
+	defer secret.Reset()
+
+	logger, hook := test.NewNullLogger()
+
+	app := &cli.App{
+		Name: "test-plugin",
+		Flags: append([]cli.Flag{
+			Secret(&cli.StringFlag{
+				Name:    "password",
+				EnvVars: []string{"PLUGIN_PASSWORD"},
+			}),
+		}, Flags()...),
+		Action: func(ctx *cli.Context) error {
+			configureLogger(ctx, logger)
+			configureLogger(ctx, logger)
+
+			logger.WithField("password", ctx.String("password")).Info("message")
+
+			return nil
+		},
+	}
+
+	assert.NoError(t, app.Run([]string{"test-plugin", "--password", "hunter2", "--repo.slug", "octocat/hello-world"}))
+	assert.Len(t, logger.Hooks[logrus.InfoLevel], 3)
+
+	if assert.Len(t, hook.AllEntries(), 1) {
+		entry := hook.LastEntry()
+		assert.Equal(t, "***", entry.Data["password"])
+		assert.Equal(t, "octocat/hello-world", entry.Data["repo"])
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/urfave/cli/v2"
+)
+
+// netrcFlags has the cli.Flags for the drone.Netrc.
+func netrcFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "netrc.machine",
+			Usage: "netrc machine",
+			EnvVars: []string{
+				"DRONE_NETRC_MACHINE",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "netrc.username",
+			Usage: "netrc username",
+			EnvVars: []string{
+				"DRONE_NETRC_USERNAME",
+			},
+		},
+		Secret(&cli.StringFlag{
+			Name:  "netrc.password",
+			Usage: "netrc password",
+			EnvVars: []string{
+				"DRONE_NETRC_PASSWORD",
+			},
+		}),
+	}
+}
+
+// netrcFromContext creates a drone.Netrc from the cli.Context.
+func netrcFromContext(ctx *cli.Context) drone.Netrc {
+	return drone.Netrc{
+		Machine:  ctx.String("netrc.machine"),
+		Username: ctx.String("netrc.username"),
+		Password: ctx.String("netrc.password"),
+	}
+}
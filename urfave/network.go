@@ -8,6 +8,7 @@ package urfave
 import (
 	"context"
 	"crypto/tls"
+	"log/slog"
 	"net"
 	"net/http"
 	"time"
@@ -18,6 +19,18 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	// defaultDialTimeout is the default time to wait for a connection.
+	defaultDialTimeout = 30 * time.Second
+
+	// defaultTLSHandshakeTimeout is the default time to wait for a TLS
+	// handshake.
+	defaultTLSHandshakeTimeout = 10 * time.Second
+
+	// defaultIdleConnTimeout is the default time an idle connection is kept.
+	defaultIdleConnTimeout = 90 * time.Second
+)
+
 // networkFlags has the cli.Flags for the drone.Network.
 func networkFlags() []cli.Flag {
 	return []cli.Flag{
@@ -26,13 +39,74 @@ func networkFlags() []cli.Flag {
 			Usage:   "skip ssl verify",
 			EnvVars: []string{"PLUGIN_SKIP_VERIFY"},
 		},
+		&cli.DurationFlag{
+			Name:    "transport.dial-timeout",
+			Usage:   "transport dial timeout",
+			Value:   defaultDialTimeout,
+			EnvVars: []string{"PLUGIN_DIAL_TIMEOUT"},
+		},
+		&cli.DurationFlag{
+			Name:    "transport.tls-handshake-timeout",
+			Usage:   "transport tls handshake timeout",
+			Value:   defaultTLSHandshakeTimeout,
+			EnvVars: []string{"PLUGIN_TLS_HANDSHAKE_TIMEOUT"},
+		},
+		&cli.DurationFlag{
+			Name:    "transport.response-header-timeout",
+			Usage:   "transport response header timeout, 0 for none",
+			EnvVars: []string{"PLUGIN_RESPONSE_HEADER_TIMEOUT"},
+		},
+		&cli.DurationFlag{
+			Name:    "transport.idle-conn-timeout",
+			Usage:   "transport idle connection timeout",
+			Value:   defaultIdleConnTimeout,
+			EnvVars: []string{"PLUGIN_IDLE_CONN_TIMEOUT"},
+		},
+		&cli.DurationFlag{
+			Name:    "transport.timeout",
+			Usage:   "overall request timeout, 0 for none",
+			EnvVars: []string{"PLUGIN_REQUEST_TIMEOUT"},
+		},
+		&cli.IntFlag{
+			Name:    "transport.max-conns-per-host",
+			Usage:   "transport max connections per host, 0 for no limit",
+			EnvVars: []string{"PLUGIN_MAX_CONNS_PER_HOST"},
+		},
+		&cli.BoolFlag{
+			Name:    "transport.http2",
+			Usage:   "transport http/2 enabled",
+			Value:   true,
+			EnvVars: []string{"PLUGIN_HTTP2"},
+		},
 	}
 }
 
 // NetworkFromContext creates a drone.Network from the cli.Context.
+//
+// Invalid settings are logged and replaced with their default value. If a
+// logger was created with LoggerFromContext it is used for logging and
+// carried by the network context, otherwise the logrus standard logger is.
+// A slog logger created with SlogFromContext is carried as well and used for
+// tracing requests.
 func NetworkFromContext(c *cli.Context) drone.Network {
+	logger := drone.LoggerFromContext(c.Context)
+
+	dialTimeout := durationFromContext(c, logger, "transport.dial-timeout", defaultDialTimeout)
+	tlsHandshakeTimeout := durationFromContext(c, logger, "transport.tls-handshake-timeout", defaultTLSHandshakeTimeout)
+	responseHeaderTimeout := durationFromContext(c, logger, "transport.response-header-timeout", 0)
+	idleConnTimeout := durationFromContext(c, logger, "transport.idle-conn-timeout", defaultIdleConnTimeout)
+	timeout := durationFromContext(c, logger, "transport.timeout", 0)
+
+	maxConnsPerHost := c.Int("transport.max-conns-per-host")
+	if maxConnsPerHost < 0 {
+		logger.WithField("value", maxConnsPerHost).Warning("invalid transport.max-conns-per-host, using no limit")
+		maxConnsPerHost = 0
+	}
+
+	http2 := c.Bool("transport.http2")
+
 	dialer := &net.Dialer{
-		Timeout:   30 * time.Second,
+		Timeout:   dialTimeout,
 		KeepAlive: 30 * time.Second,
 		DualStack: true,
 	}
@@ -41,33 +115,79 @@ func NetworkFromContext(c *cli.Context) drone.Network {
 		Proxy:                 http.ProxyFromEnvironment,
 		DialContext:           dialer.DialContext,
 		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
+		MaxConnsPerHost:       maxConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ResponseHeaderTimeout: responseHeaderTimeout,
 		ExpectContinueTimeout: 1 * time.Second,
+		ForceAttemptHTTP2:     http2,
+	}
+
+	if !http2 {
+		// A non-nil empty map disables HTTP/2 on the transport.
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
 	}
 
-	ctx := context.Background()
+	ctx := drone.WithLogger(context.Background(), logger)
 	skipVerify := c.Bool("transport.skip-verify")
 
 	if skipVerify {
-		logrus.Warning("ssl verification is turned off")
+		logger.Warning("ssl verification is turned off")
 
 		transport.TLSClientConfig = &tls.Config{
 			InsecureSkipVerify: true,
 		}
 	}
 
+	var sl *slog.Logger
+
+	if drone.HasSlog(c.Context) {
+		sl = drone.SlogFromContext(c.Context)
+		ctx = drone.WithSlog(ctx, sl)
+	}
+
 	if c.String("log-level") == logrus.TraceLevel.String() {
-		ctx = trace.HTTP(ctx)
+		if sl != nil {
+			ctx = trace.SlogHTTP(ctx)
+		} else {
+			ctx = trace.HTTP(ctx)
+		}
 	}
 
 	client := &http.Client{
 		Transport: transport,
+		Timeout:   timeout,
 	}
 
 	return drone.Network{
-		Context:    ctx,
-		SkipVerify: skipVerify,
-		Client:     client,
+		Context:               ctx,
+		SkipVerify:            skipVerify,
+		DialTimeout:           dialTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ResponseHeaderTimeout: responseHeaderTimeout,
+		IdleConnTimeout:       idleConnTimeout,
+		Timeout:               timeout,
+		MaxConnsPerHost:       maxConnsPerHost,
+		HTTP2:                 http2,
+		Client:                client,
+		Logger:                logger,
+		Slog:                  sl,
 	}
 }
+
+// durationFromContext reads a duration flag falling back to the default
+// value when a negative duration is provided.
+func durationFromContext(c *cli.Context, logger *logrus.Logger, name string, value time.Duration) time.Duration {
+	d := c.Duration(name)
+
+	if d < 0 {
+		logger.WithFields(logrus.Fields{
+			"value":   d,
+			"default": value,
+		}).Warningf("invalid %s, using default", name)
+
+		return value
+	}
+
+	return d
+}
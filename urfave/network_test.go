@@ -0,0 +1,131 @@
+package urfave
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// Test creating the network from the transport flags
+func TestNetworkFromContext(t *testing.T) {
+	tests := []struct {
+		name                  string
+		args                  []string
+		dialTimeout           time.Duration
+		tlsHandshakeTimeout   time.Duration
+		responseHeaderTimeout time.Duration
+		idleConnTimeout       time.Duration
+		timeout               time.Duration
+		maxConnsPerHost       int
+		http2                 bool
+		skipVerify            bool
+		warnings              []string
+	}{
+		{
+			name:                "defaults",
+			dialTimeout:         30 * time.Second,
+			tlsHandshakeTimeout: 10 * time.Second,
+			idleConnTimeout:     90 * time.Second,
+			http2:               true,
+		},
+		{
+			name: "custom",
+			args: []string{
+				"--transport.dial-timeout", "5s",
+				"--transport.tls-handshake-timeout", "2s",
+				"--transport.response-header-timeout", "3s",
+				"--transport.idle-conn-timeout", "1m",
+				"--transport.timeout", "2m",
+				"--transport.max-conns-per-host", "4",
+				"--transport.http2=false",
+				"--transport.skip-verify",
+			},
+			dialTimeout:           5 * time.Second,
+			tlsHandshakeTimeout:   2 * time.Second,
+			responseHeaderTimeout: 3 * time.Second,
+			idleConnTimeout:       time.Minute,
+			timeout:               2 * time.Minute,
+			maxConnsPerHost:       4,
+			skipVerify:            true,
+			warnings:              []string{"ssl verification is turned off"},
+		},
+		{
+			name: "invalid",
+			args: []string{
+				"--transport.dial-timeout", "-5s",
+				"--transport.idle-conn-timeout", "-1m",
+				"--transport.timeout", "-1s",
+				"--transport.max-conns-per-host", "-2",
+			},
+			dialTimeout:         30 * time.Second,
+			tlsHandshakeTimeout: 10 * time.Second,
+			idleConnTimeout:     90 * time.Second,
+			http2:               true,
+			warnings: []string{
+				"invalid transport.dial-timeout, using default",
+				"invalid transport.idle-conn-timeout, using default",
+				"invalid transport.timeout, using default",
+				"invalid transport.max-conns-per-host, using no limit",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var network drone.Network
+
+			logger, hook := logtest.NewNullLogger()
+
+			app := &cli.App{
+				Flags: Flags(),
+				Action: func(ctx *cli.Context) error {
+					network = NetworkFromContext(ctx)
+					return nil
+				},
+			}
+
+			ctx := drone.WithLogger(context.Background(), logger)
+			assert.NoError(t, app.RunContext(ctx, append([]string{"plugin"}, test.args...)))
+
+			assert.Equal(t, test.dialTimeout, network.DialTimeout)
+			assert.Equal(t, test.tlsHandshakeTimeout, network.TLSHandshakeTimeout)
+			assert.Equal(t, test.responseHeaderTimeout, network.ResponseHeaderTimeout)
+			assert.Equal(t, test.idleConnTimeout, network.IdleConnTimeout)
+			assert.Equal(t, test.timeout, network.Timeout)
+			assert.Equal(t, test.maxConnsPerHost, network.MaxConnsPerHost)
+			assert.Equal(t, test.http2, network.HTTP2)
+			assert.Equal(t, test.skipVerify, network.SkipVerify)
+			assert.Same(t, logger, network.Logger)
+
+			assert.Equal(t, test.timeout, network.Client.Timeout)
+
+			transport, ok := network.Client.Transport.(*http.Transport)
+			if assert.True(t, ok) {
+				assert.Equal(t, test.tlsHandshakeTimeout, transport.TLSHandshakeTimeout)
+				assert.Equal(t, test.responseHeaderTimeout, transport.ResponseHeaderTimeout)
+				assert.Equal(t, test.idleConnTimeout, transport.IdleConnTimeout)
+				assert.Equal(t, test.maxConnsPerHost, transport.MaxConnsPerHost)
+				assert.Equal(t, test.http2, transport.ForceAttemptHTTP2)
+				assert.Equal(t, test.http2, transport.TLSNextProto == nil)
+				assert.Equal(t, test.skipVerify, transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify)
+			}
+
+			warnings := []string{}
+			for _, entry := range hook.AllEntries() {
+				warnings = append(warnings, entry.Message)
+			}
+
+			if test.warnings == nil {
+				test.warnings = []string{}
+			}
+
+			assert.Equal(t, test.warnings, warnings)
+		})
+	}
+}
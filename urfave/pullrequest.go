@@ -0,0 +1,77 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/urfave/cli/v2"
+)
+
+// pullRequestFlags has the cli.Flags for the drone.PullRequest.
+func pullRequestFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "pull-request.title",
+			Usage: "pull request title",
+			EnvVars: []string{
+				"DRONE_PULL_REQUEST_TITLE",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "pull-request.link",
+			Usage: "pull request link",
+			EnvVars: []string{
+				"DRONE_PULL_REQUEST_LINK",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "pull-request.source-repo",
+			Usage: "pull request source repo",
+			EnvVars: []string{
+				"DRONE_PULL_REQUEST_SOURCE_REPO",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "pull-request.target-repo",
+			Usage: "pull request target repo",
+			EnvVars: []string{
+				"DRONE_PULL_REQUEST_TARGET_REPO",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "pull-request.head-sha",
+			Usage: "pull request head sha",
+			EnvVars: []string{
+				"DRONE_PULL_REQUEST_HEAD_SHA",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "pull-request.base-sha",
+			Usage: "pull request base sha",
+			EnvVars: []string{
+				"DRONE_PULL_REQUEST_BASE_SHA",
+			},
+		},
+	}
+}
+
+// pullRequestFromContext creates a drone.PullRequest from the cli.Context.
+//
+// The number and branches are read from the build flags, the remaining
+// defaults are filled by PipelineFromContext.
+func pullRequestFromContext(ctx *cli.Context) drone.PullRequest {
+	return drone.PullRequest{
+		Number:       ctx.Int("build.pull-request"),
+		Title:        ctx.String("pull-request.title"),
+		Link:         ctx.String("pull-request.link"),
+		SourceRepo:   ctx.String("pull-request.source-repo"),
+		TargetRepo:   ctx.String("pull-request.target-repo"),
+		SourceBranch: ctx.String("build.source-branch"),
+		TargetBranch: ctx.String("build.target-branch"),
+		HeadSHA:      ctx.String("pull-request.head-sha"),
+		BaseSHA:      ctx.String("pull-request.base-sha"),
+	}
+}
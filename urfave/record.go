@@ -0,0 +1,322 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/harness"
+	"github.com/drone-plugins/drone-plugin-lib/provider"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	v3 "github.com/harness/godotenv/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Recording is a captured pipeline environment that can be replayed.
+type Recording struct {
+	// Pipeline resolved when the recording was made.
+	//
+	// This is informational, the pipeline is recreated from Environ when
+	// replaying.
+	Pipeline drone.Pipeline `json:"pipeline" yaml:"pipeline"`
+
+	// Environ contains the environment variables read by the plugin.
+	Environ map[string]string `json:"environ" yaml:"environ"`
+
+	// Secrets contains the names of the environment variables whose values
+	// were redacted. These need to be provided when replaying.
+	Secrets []string `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+}
+
+// recordFlags has the cli.Flags for recording and replaying.
+func recordFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "record",
+			Usage:   "file to record the pipeline environment to",
+			EnvVars: []string{"PLUGIN_RECORD"},
+		},
+		&cli.StringFlag{
+			Name:    "replay",
+			Usage:   "file to replay the pipeline environment from",
+			EnvVars: []string{"PLUGIN_REPLAY"},
+		},
+		&cli.StringFlag{
+			Name:    "replay-secrets",
+			Usage:   ".env file providing the secrets when replaying",
+			EnvVars: []string{"PLUGIN_REPLAY_SECRETS"},
+		},
+	}
+}
+
+// recordIgnored are the environment variables never recorded.
+//
+// This includes the files the host CI provides to the step, as these do not
+// exist when replaying.
+var recordIgnored = map[string]struct{}{
+	"PLUGIN_RECORD":                 {},
+	"PLUGIN_REPLAY":                 {},
+	"PLUGIN_REPLAY_SECRETS":         {},
+	envFileEnv:                      {},
+	harness.DroneOutputFile:         {},
+	harness.HarnessOutputSecretFile: {},
+	harness.MetadataFile:            {},
+	"GITHUB_ENV":                    {},
+	"GITHUB_EVENT_PATH":             {},
+	"GITHUB_OUTPUT":                 {},
+	"GITHUB_PATH":                   {},
+	"GITHUB_STATE":                  {},
+	"GITHUB_STEP_SUMMARY":           {},
+}
+
+// RecordFromContext writes the pipeline environment to the file given by
+// the record flag.
+//
+// The environment variables read by any of the flags, along with all PLUGIN_
+// variables and the variables of the host CI, are recorded so the pipeline is
+// rebuilt by the same provider when replaying. Values of flags marked with
+// Secret, variables named like secrets, or values containing a registered
+// secret, are redacted. Nothing is written if the record flag is not set.
+func RecordFromContext(ctx *cli.Context) error {
+	path := ctx.String("record")
+	if path == "" {
+		return nil
+	}
+
+	secretsFromContext(ctx)
+
+	rec := Recording{
+		Pipeline: PipelineFromContext(ctx),
+		Environ:  map[string]string{},
+	}
+
+	for _, env := range recordedEnvVars(ctx) {
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			continue
+		}
+
+		if IsSecretSetting(env) || secret.Mask(value) != value {
+			value = placeholder(env)
+			rec.Secrets = append(rec.Secrets, env)
+		}
+
+		rec.Environ[env] = value
+	}
+
+	sort.Strings(rec.Secrets)
+
+	// The pipeline can include credentials embedded in URLs.
+	rec.Pipeline.Repo.HTTPURL = secret.Mask(rec.Pipeline.Repo.HTTPURL)
+
+	if err := WriteRecording(path, rec); err != nil {
+		return err
+	}
+
+	drone.LoggerFromContext(ctx.Context).WithFields(logrus.Fields{
+		"file":    path,
+		"secrets": len(rec.Secrets),
+	}).Info("recorded pipeline environment")
+
+	return nil
+}
+
+// ReplayFromContext loads the pipeline environment from the file given by
+// the replay flag and applies it.
+//
+// Recorded values are set in the environment and on any flag reading them,
+// unless the variable is already present in the environment or the flag was
+// set explicitly. Redacted secrets are read from the replay-secrets file or
+// the environment. Nothing happens if the replay flag is not set.
+func ReplayFromContext(ctx *cli.Context) error {
+	path := ctx.String("replay")
+	if path == "" {
+		return nil
+	}
+
+	rec, err := ReadRecording(path)
+	if err != nil {
+		return err
+	}
+
+	secrets := map[string]string{}
+
+	if secretsPath := ctx.String("replay-secrets"); secretsPath != "" {
+		secrets, err = v3.Read(secretsPath)
+		if err != nil {
+			return fmt.Errorf("failed to read replay secrets %s: %w", secretsPath, err)
+		}
+	}
+
+	for _, name := range rec.Secrets {
+		value, ok := secrets[name]
+		if !ok {
+			value, ok = os.LookupEnv(name)
+		}
+
+		if !ok {
+			drone.LoggerFromContext(ctx.Context).WithField("name", name).Warning("no value provided for recorded secret")
+			delete(rec.Environ, name)
+			continue
+		}
+
+		secret.Register(value)
+		rec.Environ[name] = value
+	}
+
+	for env, value := range rec.Environ {
+		if _, ok := os.LookupEnv(env); ok {
+			continue
+		}
+
+		if err := os.Setenv(env, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", env, err)
+		}
+	}
+
+	for _, flag := range contextFlags(ctx) {
+		names := flag.Names()
+		if len(names) == 0 || ctx.IsSet(names[0]) {
+			continue
+		}
+
+		for _, env := range flagEnvVars(flag) {
+			value, ok := rec.Environ[env]
+			if !ok {
+				continue
+			}
+
+			if err := ctx.Set(names[0], value); err != nil {
+				return fmt.Errorf("failed to replay %s: %w", env, err)
+			}
+
+			break
+		}
+	}
+
+	secretsFromContext(ctx)
+
+	drone.LoggerFromContext(ctx.Context).WithField("file", path).Info("replaying pipeline environment")
+
+	return nil
+}
+
+// WriteRecording writes the recording to the file.
+//
+// The file is written as YAML if it has a .yml or .yaml extension, otherwise
+// it is written as JSON.
+func WriteRecording(path string, rec Recording) error {
+	var (
+		b   []byte
+		err error
+	)
+
+	if isYAML(path) {
+		b, err = yaml.Marshal(rec)
+	} else {
+		b, err = json.MarshalIndent(rec, "", "  ")
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal recording: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s directory for recording: %w", dir, err)
+		}
+	}
+
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("failed to write recording %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadRecording reads the recording from the file.
+func ReadRecording(path string) (Recording, error) {
+	rec := Recording{}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return rec, fmt.Errorf("failed to read recording %s: %w", path, err)
+	}
+
+	if isYAML(path) {
+		err = yaml.Unmarshal(b, &rec)
+	} else {
+		err = json.Unmarshal(b, &rec)
+	}
+
+	if err != nil {
+		return rec, fmt.Errorf("failed to parse recording %s: %w", path, err)
+	}
+
+	if rec.Environ == nil {
+		rec.Environ = map[string]string{}
+	}
+
+	return rec, nil
+}
+
+// recordedEnvVars returns the sorted environment variables to record.
+func recordedEnvVars(ctx *cli.Context) []string {
+	set := map[string]struct{}{}
+
+	for _, flag := range contextFlags(ctx) {
+		for _, env := range flagEnvVars(flag) {
+			set[env] = struct{}{}
+		}
+	}
+
+	for _, kv := range os.Environ() {
+		env, _, _ := strings.Cut(kv, "=")
+
+		if strings.HasPrefix(env, settingPrefix) || provider.IsHostEnv(env) {
+			set[env] = struct{}{}
+		}
+	}
+
+	envs := make([]string, 0, len(set))
+	for env := range set {
+		if _, ok := recordIgnored[env]; !ok {
+			envs = append(envs, env)
+		}
+	}
+
+	sort.Strings(envs)
+
+	return envs
+}
+
+// flagEnvVars returns the environment variables read by the flag.
+func flagEnvVars(flag cli.Flag) []string {
+	if f, ok := flag.(cli.DocGenerationFlag); ok {
+		return f.GetEnvVars()
+	}
+
+	return nil
+}
+
+// placeholder returns the value recorded in place of a secret.
+func placeholder(env string) string {
+	return "${secret:" + env + "}"
+}
+
+// isYAML checks if the path has a YAML extension.
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yml" || ext == ".yaml"
+}
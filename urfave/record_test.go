@@ -0,0 +1,125 @@
+package urfave
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// Test recording the environment of a GitHub Actions run and replaying it
+// with local secrets
+func TestRecordReplay(t *testing.T) {
+	defer secret.Reset()
+
+	dronetest.ClearEnv(t)
+
+	dir := t.TempDir()
+	recording := filepath.Join(dir, "recording.json")
+	secrets := filepath.Join(dir, "secrets.env")
+
+	var (
+		pipeline drone.Pipeline
+		password string
+		webhook  string
+		token    string
+	)
+
+	newApp := func() *cli.App {
+		return &cli.App{
+			Flags: append([]cli.Flag{
+				Secret(&cli.StringFlag{
+					Name:    "password",
+					EnvVars: []string{"PLUGIN_PASSWORD"},
+				}),
+				Secret(&cli.StringFlag{
+					Name:    "webhook-url",
+					EnvVars: []string{"PLUGIN_WEBHOOK_URL"},
+				}),
+				Secret(&cli.StringFlag{
+					Name:    "github-token",
+					EnvVars: []string{"GITHUB_TOKEN"},
+				}),
+			}, Flags()...),
+			Action: func(ctx *cli.Context) error {
+				if err := ReplayFromContext(ctx); err != nil {
+					return err
+				}
+
+				if err := RecordFromContext(ctx); err != nil {
+					return err
+				}
+
+				pipeline = PipelineFromContext(ctx)
+				password = ctx.String("password")
+				webhook = ctx.String("webhook-url")
+				token = ctx.String("github-token")
+
+				return nil
+			},
+		}
+	}
+
+	env := map[string]string{
+		"GITHUB_ACTIONS":      "true",
+		"GITHUB_REPOSITORY":   "octocat/hello-world",
+		"GITHUB_RUN_NUMBER":   "42",
+		"GITHUB_SHA":          "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		"GITHUB_EVENT_PATH":   filepath.Join(dir, "event.json"),
+		"GITHUB_STEP_SUMMARY": filepath.Join(dir, "summary.md"),
+		"CI_JOB_TOKEN":        "job-token",
+		"DRONE_FAILED_STEPS":  "test,lint",
+		"PLUGIN_PASSWORD":     "hunter2",
+		"PLUGIN_API_TOKEN":    "undeclared",
+		"PLUGIN_WEBHOOK_URL":  "https://hooks.example.com/abc",
+		"GITHUB_TOKEN":        "ghp_abc",
+	}
+
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	assert.NoError(t, os.WriteFile(env["GITHUB_EVENT_PATH"], []byte("{}"), 0600))
+	assert.NoError(t, newApp().Run([]string{"plugin", "--record", recording}))
+
+	rec, err := ReadRecording(recording)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", rec.Environ["GITHUB_ACTIONS"])
+	assert.Equal(t, "octocat/hello-world", rec.Environ["GITHUB_REPOSITORY"])
+	assert.NotContains(t, rec.Environ, "GITHUB_EVENT_PATH")
+	assert.NotContains(t, rec.Environ, "GITHUB_STEP_SUMMARY")
+	assert.Equal(t, "${secret:PLUGIN_PASSWORD}", rec.Environ["PLUGIN_PASSWORD"])
+	assert.Equal(t, "${secret:PLUGIN_API_TOKEN}", rec.Environ["PLUGIN_API_TOKEN"])
+	assert.Equal(t, "${secret:PLUGIN_WEBHOOK_URL}", rec.Environ["PLUGIN_WEBHOOK_URL"])
+	assert.Equal(t, "${secret:GITHUB_TOKEN}", rec.Environ["GITHUB_TOKEN"])
+	assert.Equal(t, []string{"CI_JOB_TOKEN", "GITHUB_TOKEN", "PLUGIN_API_TOKEN", "PLUGIN_PASSWORD", "PLUGIN_WEBHOOK_URL"}, rec.Secrets)
+
+	b, err := os.ReadFile(recording)
+	assert.NoError(t, err)
+
+	for _, value := range []string{"hunter2", "undeclared", "hooks.example.com", "ghp_abc", "job-token"} {
+		assert.NotContains(t, string(b), value)
+	}
+
+	for k := range env {
+		os.Unsetenv(k)
+	}
+
+	assert.NoError(t, os.WriteFile(secrets, []byte("PLUGIN_PASSWORD=local\nPLUGIN_WEBHOOK_URL=https://localhost\nGITHUB_TOKEN=local-token\n"), 0600))
+	assert.NoError(t, newApp().Run([]string{"plugin", "--replay", recording, "--replay-secrets", secrets}))
+
+	assert.Equal(t, drone.HostGitHub, pipeline.System.Kind)
+	assert.Equal(t, "octocat/hello-world", pipeline.Repo.Slug)
+	assert.Equal(t, 42, pipeline.Build.Number)
+	assert.Equal(t, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", pipeline.Commit.SHA)
+	assert.Equal(t, []string{"test", "lint"}, pipeline.Build.FailedSteps)
+	assert.Equal(t, "local", password)
+	assert.Equal(t, "https://localhost", webhook)
+	assert.Equal(t, "local-token", token)
+	assert.Equal(t, "octocat/hello-world", os.Getenv("GITHUB_REPOSITORY"))
+}
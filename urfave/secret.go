@@ -0,0 +1,178 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/drone-plugins/drone-plugin-lib/provider"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	secretFlagsMu sync.RWMutex
+	secretFlags   = map[string]struct{}{}
+	secretEnvVars = map[string]struct{}{}
+)
+
+// secretSettingNames are the parts of a setting name that mark it as secret.
+var secretSettingNames = []string{
+	"PASSWORD",
+	"SECRET",
+	"TOKEN",
+	"KEY",
+	"CREDENTIAL",
+	"AUTH",
+	"PRIVATE",
+	"NETRC",
+}
+
+// secretHostEnvParts are the parts of a host CI variable name that mark it as
+// secret.
+//
+// Host variables are matched on whole parts of the name, as names like
+// CI_COMMIT_AUTHOR would otherwise be matched.
+var secretHostEnvParts = []string{
+	"PASSWORD",
+	"SECRET",
+	"TOKEN",
+	"KEY",
+	"JWT",
+}
+
+// Secret marks the flag as holding a secret value.
+//
+// The values of secret flags are masked in all log output once
+// LoggingFromContext is called.
+//
+//	app.Flags = []cli.Flag{
+//		urfave.Secret(&cli.StringFlag{
+//			Name:    "password",
+//			EnvVars: []string{"PLUGIN_PASSWORD"},
+//		}),
+//	}
+func Secret(flag cli.Flag) cli.Flag {
+	secretFlagsMu.Lock()
+	defer secretFlagsMu.Unlock()
+
+	for _, name := range flag.Names() {
+		secretFlags[name] = struct{}{}
+	}
+
+	for _, env := range flagEnvVars(flag) {
+		secretEnvVars[env] = struct{}{}
+	}
+
+	return flag
+}
+
+// IsSecret checks if the flag name was marked as a secret.
+func IsSecret(name string) bool {
+	secretFlagsMu.RLock()
+	defer secretFlagsMu.RUnlock()
+
+	_, ok := secretFlags[name]
+
+	return ok
+}
+
+// IsSecretSetting checks if the environment variable looks like it holds a
+// secret.
+//
+// This is the case if the variable is read by a flag marked with Secret, or
+// it is a PLUGIN_ setting named like a flag marked with Secret or containing
+// a part like TOKEN or PASSWORD. Variables of the host CI are secret if a
+// part of their name, like TOKEN in GITHUB_TOKEN, marks them as secret.
+func IsSecretSetting(env string) bool {
+	secretFlagsMu.RLock()
+	_, ok := secretEnvVars[env]
+	secretFlagsMu.RUnlock()
+
+	if ok {
+		return true
+	}
+
+	if name, ok := strings.CutPrefix(env, settingPrefix); ok {
+		name = strings.ToLower(name)
+
+		if IsSecret(name) || IsSecret(strings.ReplaceAll(name, "_", "-")) {
+			return true
+		}
+
+		for _, s := range secretSettingNames {
+			if strings.Contains(strings.ToUpper(name), s) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if provider.IsHostEnv(env) {
+		for _, part := range strings.Split(env, "_") {
+			for _, s := range secretHostEnvParts {
+				if part == s {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// secretsFromContext registers the values of secret flags, along with any
+// credentials embedded in URLs, for masking.
+func secretsFromContext(ctx *cli.Context) {
+	for _, flag := range contextFlags(ctx) {
+		names := flag.Names()
+		if len(names) == 0 {
+			continue
+		}
+
+		values := flagValues(ctx, names[0])
+		secretFlag := IsSecret(names[0])
+
+		for _, value := range values {
+			if secretFlag {
+				secret.Register(value)
+			}
+
+			secret.RegisterURL(value)
+		}
+	}
+}
+
+// contextFlags returns all the cli.Flags available within the cli.Context.
+func contextFlags(ctx *cli.Context) []cli.Flag {
+	var flags []cli.Flag
+
+	for _, c := range ctx.Lineage() {
+		if c.Command != nil {
+			flags = append(flags, c.Command.Flags...)
+		}
+	}
+
+	if ctx.App != nil {
+		flags = append(flags, ctx.App.Flags...)
+	}
+
+	return flags
+}
+
+// flagValues returns the string values for the flag.
+func flagValues(ctx *cli.Context, name string) []string {
+	switch value := ctx.Value(name).(type) {
+	case string:
+		return []string{value}
+	case cli.StringSlice:
+		return ctx.StringSlice(name)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/drone-plugins/drone-plugin-lib/trace"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// SlogLoggingFromContext sets the default slog logger from the cli.Context.
+//
+// This is the slog equivalent of LoggingFromContext and uses the same level,
+// format and timestamp flags.
+func SlogLoggingFromContext(ctx *cli.Context) {
+	slog.SetDefault(newSlog(ctx, os.Stderr))
+}
+
+// SlogFromContext creates a slog.Logger from the cli.Context.
+//
+// The logger is configured the same as SlogLoggingFromContext but does not
+// modify the default slog logger. It is also attached to ctx.Context so
+// NetworkFromContext traces requests through it.
+//
+// If ctx.Context already carries a slog logger, passed in through cli.App
+// RunContext, the returned logger writes through its handler instead.
+func SlogFromContext(ctx *cli.Context) *slog.Logger {
+	if ctx.Context == nil {
+		ctx.Context = context.Background()
+	}
+
+	var logger *slog.Logger
+	if drone.HasSlog(ctx.Context) {
+		logger = wrapSlog(ctx, drone.SlogFromContext(ctx.Context).Handler())
+	} else {
+		logger = newSlog(ctx, os.Stderr)
+	}
+
+	ctx.Context = drone.WithSlog(ctx.Context, logger)
+
+	return logger
+}
+
+// newSlog creates a slog.Logger writing to w.
+func newSlog(ctx *cli.Context, w io.Writer) *slog.Logger {
+	level, _ := slogLevelFromContext(ctx)
+	timestamp := ctx.Bool("log-timestamp")
+
+	opts := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) != 0 {
+				return a
+			}
+
+			switch a.Key {
+			case slog.TimeKey:
+				if !timestamp {
+					return slog.Attr{}
+				}
+			case slog.LevelKey:
+				if lvl, ok := a.Value.Any().(slog.Level); ok && lvl == trace.LevelTrace {
+					return slog.String(slog.LevelKey, "TRACE")
+				}
+			}
+
+			return a
+		},
+	}
+
+	var handler slog.Handler
+
+	switch ctx.String("log-format") {
+	case LogFormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		handler = slog.NewTextHandler(w, opts)
+	}
+
+	return wrapSlog(ctx, handler)
+}
+
+// wrapSlog creates a slog.Logger masking secrets and adding the standard
+// attributes before passing records to the handler.
+func wrapSlog(ctx *cli.Context, handler slog.Handler) *slog.Logger {
+	secretsFromContext(ctx)
+
+	logger := slog.New(secret.NewSlogHandler(handler)).With(slogAttrsFromContext(ctx)...)
+
+	if _, levelErr := slogLevelFromContext(ctx); levelErr {
+		logger.Warn("invalid log level, using info", "log-level", ctx.String("log-level"))
+	}
+
+	if format := ctx.String("log-format"); format != LogFormatText && format != LogFormatJSON && format != LogFormatLogfmt && format != "" {
+		logger.Warn("invalid log format, using text", "log-format", format)
+	}
+
+	return logger
+}
+
+// slogLevelFromContext parses the log level. The same level names as logrus
+// are accepted, the second return value reports an invalid level.
+func slogLevelFromContext(ctx *cli.Context) (slog.Level, bool) {
+	level := ctx.String("log-level")
+
+	if level == "" {
+		return slog.LevelInfo, false
+	}
+
+	lvl, err := logrus.ParseLevel(level)
+
+	if err != nil {
+		return slog.LevelInfo, true
+	}
+
+	switch lvl {
+	case logrus.TraceLevel:
+		return trace.LevelTrace, false
+	case logrus.DebugLevel:
+		return slog.LevelDebug, false
+	case logrus.InfoLevel:
+		return slog.LevelInfo, false
+	case logrus.WarnLevel:
+		return slog.LevelWarn, false
+	default:
+		return slog.LevelError, false
+	}
+}
+
+// slogAttrsFromContext creates the standard log attributes from the
+// pipeline.
+func slogAttrsFromContext(ctx *cli.Context) []any {
+	fields := logFieldsFromContext(ctx)
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	attrs := make([]any, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, fields[k]))
+	}
+
+	return attrs
+}
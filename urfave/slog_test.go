@@ -0,0 +1,118 @@
+package urfave
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/drone-plugins/drone-plugin-lib/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// slogApp creates an app with a secret password flag running action.
+func slogApp(action cli.ActionFunc) *cli.App {
+	return &cli.App{
+		Name: "test-plugin",
+		Flags: append([]cli.Flag{
+			Secret(&cli.StringFlag{
+				Name:    "password",
+				EnvVars: []string{"PLUGIN_PASSWORD"},
+			}),
+		}, Flags()...),
+		Action: action,
+	}
+}
+
+// Test the slog logger uses the level, format and masks secrets
+func TestNewSlog(t *testing.T) {
+	defer secret.Reset()
+
+	var buf bytes.Buffer
+
+	app := slogApp(func(ctx *cli.Context) error {
+		logger := newSlog(ctx, &buf)
+
+		logger.Log(context.Background(), trace.LevelTrace, "trace")
+		logger.Debug("debug", "password", ctx.String("password"))
+
+		return nil
+	})
+
+	err := app.Run([]string{
+		"test-plugin",
+		"--log-level", "debug",
+		"--log-format", "json",
+		"--password", "hunter2",
+		"--repo.slug", "octocat/hello-world",
+	})
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 1) {
+		record := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+		assert.Equal(t, "DEBUG", record["level"])
+		assert.Equal(t, "debug", record["msg"])
+		assert.Equal(t, "***", record["password"])
+		assert.Equal(t, "test-plugin", record["plugin"])
+		assert.Equal(t, "octocat/hello-world", record["repo"])
+		assert.NotContains(t, record, "time")
+	}
+}
+
+// Test invalid log settings are reported and the trace level is named
+func TestNewSlogInvalid(t *testing.T) {
+	var buf bytes.Buffer
+
+	app := slogApp(func(ctx *cli.Context) error {
+		newSlog(ctx, &buf)
+		return nil
+	})
+
+	assert.NoError(t, app.Run([]string{"test-plugin", "--log-level", "verbose", "--log-format", "xml"}))
+	assert.Contains(t, buf.String(), `msg="invalid log level, using info" plugin=test-plugin log-level=verbose`)
+	assert.Contains(t, buf.String(), `msg="invalid log format, using text" plugin=test-plugin log-format=xml`)
+
+	buf.Reset()
+
+	app = slogApp(func(ctx *cli.Context) error {
+		newSlog(ctx, &buf).Log(context.Background(), trace.LevelTrace, "trace")
+		return nil
+	})
+
+	assert.NoError(t, app.Run([]string{"test-plugin", "--log-level", "trace"}))
+	assert.Contains(t, buf.String(), "level=TRACE msg=trace")
+}
+
+// Test the slog logger writes through the handler carried by the context
+func TestSlogFromContext(t *testing.T) {
+	defer secret.Reset()
+
+	var buf bytes.Buffer
+	carried := slog.New(slog.NewTextHandler(&buf, nil))
+
+	app := slogApp(func(ctx *cli.Context) error {
+		logger := SlogFromContext(ctx)
+
+		assert.Same(t, logger, drone.SlogFromContext(ctx.Context))
+		assert.Same(t, logger, NetworkFromContext(ctx).Slog)
+
+		logger.Info("message", "password", ctx.String("password"))
+
+		return nil
+	})
+
+	ctx := drone.WithSlog(context.Background(), carried)
+	assert.NoError(t, app.RunContext(ctx, []string{"test-plugin", "--password", "hunter2", "--build.number", "42"}))
+
+	assert.Contains(t, buf.String(), "msg=message")
+	assert.Contains(t, buf.String(), "build=42")
+	assert.Contains(t, buf.String(), "password=***")
+	assert.NotContains(t, buf.String(), "hunter2")
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drone-plugins/drone-plugin-lib/git"
+	"github.com/urfave/cli/v2"
+)
+
+// sshFlags has the cli.Flags for the git ssh setup.
+func sshFlags() []cli.Flag {
+	return []cli.Flag{
+		Secret(&cli.StringFlag{
+			Name:    "ssh.key",
+			Usage:   "ssh private key for git",
+			EnvVars: []string{"PLUGIN_SSH_KEY"},
+		}),
+		Secret(&cli.StringFlag{
+			Name:    "ssh.known-hosts",
+			Usage:   "ssh known hosts for git",
+			EnvVars: []string{"PLUGIN_SSH_KNOWN_HOSTS"},
+		}),
+		&cli.BoolFlag{
+			Name:    "ssh.skip-verify",
+			Usage:   "skip verifying the ssh host key",
+			EnvVars: []string{"PLUGIN_SSH_SKIP_VERIFY"},
+		},
+	}
+}
+
+// SSHFromContext installs the ssh key and known hosts from the cli.Context
+// for git.
+//
+// Nil is returned if no ssh key is set. Without known hosts an error is
+// returned, unless the ssh.skip-verify flag is set to connect without
+// verifying the host key. The returned git.SSH provides the GIT_SSH_COMMAND
+// to run git with.
+func SSHFromContext(ctx *cli.Context) (*git.SSH, error) {
+	key := ctx.String("ssh.key")
+	if strings.TrimSpace(key) == "" {
+		return nil, nil
+	}
+
+	if knownHosts := ctx.String("ssh.known-hosts"); strings.TrimSpace(knownHosts) != "" {
+		return git.SetupSSH(key, knownHosts)
+	}
+
+	if !ctx.Bool("ssh.skip-verify") {
+		return nil, fmt.Errorf("ssh known hosts are required unless ssh.skip-verify is set")
+	}
+
+	return git.SetupSSHInsecure(ctx.Context, key)
+}
@@ -0,0 +1,84 @@
+package urfave
+
+import (
+	"testing"
+
+	"github.com/drone-plugins/drone-plugin-lib/cleanup"
+	"github.com/drone-plugins/drone-plugin-lib/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// Test installing the ssh key from the settings
+func TestSSHFromContext(t *testing.T) {
+	defer secret.Reset()
+	defer cleanup.Run()
+
+	tests := []struct {
+		env      map[string]string
+		err      bool
+		nil      bool
+		insecure bool
+	}{
+		{
+			env: map[string]string{},
+			nil: true,
+		},
+		{
+			env: map[string]string{
+				"PLUGIN_SSH_KEY":         "-----BEGIN KEY-----\\nabc\\n-----END KEY-----",
+				"PLUGIN_SSH_KNOWN_HOSTS": "github.com ssh-ed25519 AAAA",
+			},
+		},
+		{
+			env: map[string]string{
+				"PLUGIN_SSH_KEY": "-----BEGIN KEY-----\\nabc\\n-----END KEY-----",
+			},
+			err: true,
+		},
+		{
+			env: map[string]string{
+				"PLUGIN_SSH_KEY":         "-----BEGIN KEY-----\\nabc\\n-----END KEY-----",
+				"PLUGIN_SSH_SKIP_VERIFY": "true",
+			},
+			insecure: true,
+		},
+	}
+
+	for _, test := range tests {
+		for _, env := range []string{"PLUGIN_SSH_KEY", "PLUGIN_SSH_KNOWN_HOSTS", "PLUGIN_SSH_SKIP_VERIFY"} {
+			t.Setenv(env, test.env[env])
+		}
+
+		app := &cli.App{
+			Flags: Flags(),
+			Action: func(ctx *cli.Context) error {
+				s, err := SSHFromContext(ctx)
+
+				if test.err {
+					assert.Error(t, err)
+					return nil
+				}
+
+				assert.NoError(t, err)
+
+				if test.nil {
+					assert.Nil(t, s)
+					return nil
+				}
+
+				if assert.NotNil(t, s) {
+					assert.Equal(t, test.insecure, s.Insecure)
+					assert.Equal(t, test.insecure, s.KnownHostsFile == "")
+				}
+
+				return nil
+			},
+		}
+
+		assert.NoError(t, app.Run([]string{"plugin"}))
+	}
+
+	assert.True(t, IsSecretSetting("PLUGIN_SSH_KEY"))
+	assert.True(t, IsSecretSetting("PLUGIN_SSH_KNOWN_HOSTS"))
+}
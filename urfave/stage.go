@@ -6,8 +6,6 @@
 package urfave
 
 import (
-	"time"
-
 	"github.com/drone-plugins/drone-plugin-lib/drone"
 	"github.com/urfave/cli/v2"
 )
@@ -121,9 +119,9 @@ func stageFromContext(ctx *cli.Context) drone.Stage {
 		Arch:      ctx.String("stage.arch"),
 		Variant:   ctx.String("stage.variant"),
 		Version:   ctx.String("stage.version"),
-		Status:    ctx.String("stage.status"),
-		Started:   time.Unix(ctx.Int64("stage.started"), 0),
-		Finished:  time.Unix(ctx.Int64("stage.finished"), 0),
+		Status:    parseFromContext(ctx, "stage.status", drone.ParseStatus),
+		Started:   timeFromContext(ctx, "stage.started"),
+		Finished:  timeFromContext(ctx, "stage.finished"),
 		DependsOn: ctx.StringSlice("stage.depends-on"),
 	}
 }
@@ -7,6 +7,7 @@ package urfave
 
 import (
 	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/provider"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,22 +23,44 @@ func Flags() []cli.Flag {
 	flags = append(flags, semVerFlags()...)
 	flags = append(flags, calVerFlags()...)
 	flags = append(flags, systemFlags()...)
+	flags = append(flags, netrcFlags()...)
+	flags = append(flags, sshFlags()...)
+	flags = append(flags, workspaceFlags()...)
+	flags = append(flags, pullRequestFlags()...)
 	flags = append(flags, networkFlags()...)
 	flags = append(flags, loggingFlags()...)
+	flags = append(flags, recordFlags()...)
+	flags = append(flags, envFileFlags()...)
+	flags = append(flags, expandFlags()...)
 
 	return flags
 }
 
 // PipelineFromContext creates a drone.Pipeline from the cli.Context.
+//
+// When running within another host CI any fields missing from the DRONE_*
+// environment variables are filled by the provider for that host. Missing
+// pull request values are filled using drone.Pipeline FillPullRequest.
 func PipelineFromContext(ctx *cli.Context) drone.Pipeline {
-	return drone.Pipeline{
-		Build:  buildFromContext(ctx),
-		Repo:   repoFromContext(ctx),
-		Commit: commitFromContext(ctx),
-		Stage:  stageFromContext(ctx),
-		Step:   stepFromContext(ctx),
-		SemVer: semVerFromContext(ctx),
-		CalVer: calVerFromContext(ctx),
-		System: systemFromContext(ctx),
+	pipeline := drone.Pipeline{
+		Build:       buildFromContext(ctx),
+		Repo:        repoFromContext(ctx),
+		Commit:      commitFromContext(ctx),
+		Stage:       stageFromContext(ctx),
+		Step:        stepFromContext(ctx),
+		SemVer:      semVerFromContext(ctx),
+		CalVer:      calVerFromContext(ctx),
+		System:      systemFromContext(ctx),
+		Netrc:       netrcFromContext(ctx),
+		Workspace:   workspaceFromContext(ctx),
+		PullRequest: pullRequestFromContext(ctx),
 	}
+
+	if err := provider.Fill(&pipeline); err != nil {
+		drone.LoggerFromContext(ctx.Context).WithError(err).Warning("failed to read pipeline from host environment")
+	}
+
+	pipeline.FillPullRequest()
+
+	return pipeline
 }
@@ -0,0 +1,140 @@
+package urfave
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/drone-plugins/drone-plugin-lib/dronetest"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+// Test the pipeline environment is read back into the same pipeline
+func TestPipelineEnvironRoundTrip(t *testing.T) {
+	started := time.Unix(1577872860, 0)
+
+	pipeline := drone.Pipeline{
+		Build: drone.Build{
+			Branch:       "main",
+			Number:       7,
+			Event:        drone.EventPush,
+			Status:       drone.StatusSuccess,
+			Created:      started,
+			Started:      started,
+			Finished:     started.Add(time.Hour),
+			FailedStages: []string{"build"},
+			FailedSteps:  []string{"test", "lint"},
+		},
+		Repo: drone.Repo{
+			Slug:    "octocat/hello-world",
+			Owner:   "octocat",
+			Name:    "hello-world",
+			HTTPURL: "https://github.com/octocat/hello-world.git",
+			Private: true,
+		},
+		Commit: drone.Commit{
+			SHA:     "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			Ref:     "refs/heads/main",
+			Message: drone.ParseMessage("Update README\n\nAdd usage section"),
+			Author: drone.Author{
+				Username: "octocat",
+			},
+		},
+		Stage: drone.Stage{
+			Name:      "default",
+			Started:   started,
+			Finished:  started.Add(time.Hour),
+			DependsOn: []string{"setup"},
+		},
+		Step: drone.Step{
+			Name:   "publish",
+			Number: 2,
+		},
+		SemVer: drone.SemVer{
+			Version: "1.2.3",
+			Short:   "1.2.3",
+		},
+		System: drone.System{
+			Host: "drone.example.com",
+			Kind: drone.HostDrone,
+		},
+	}
+
+	dronetest.ClearEnv(t)
+
+	for _, kv := range pipeline.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		t.Setenv(k, v)
+	}
+
+	var actual drone.Pipeline
+
+	app := &cli.App{
+		Flags: Flags(),
+		Action: func(ctx *cli.Context) error {
+			actual = PipelineFromContext(ctx)
+			return nil
+		},
+	}
+
+	assert.NoError(t, app.Run([]string{"plugin"}))
+	assert.Equal(t, pipeline, actual)
+}
+
+// Test unknown build values are kept and logged
+func TestPipelineUnknownEvent(t *testing.T) {
+	t.Setenv("DRONE_BUILD_EVENT", "pull-request")
+	t.Setenv("DRONE_BUILD_STATUS", "success")
+
+	logger, hook := test.NewNullLogger()
+
+	var actual drone.Pipeline
+
+	app := &cli.App{
+		Flags: Flags(),
+		Before: func(ctx *cli.Context) error {
+			ctx.Context = drone.WithLogger(ctx.Context, logger)
+			return nil
+		},
+		Action: func(ctx *cli.Context) error {
+			actual = PipelineFromContext(ctx)
+			return nil
+		},
+	}
+
+	assert.NoError(t, app.Run([]string{"plugin"}))
+	assert.Equal(t, drone.Event("pull-request"), actual.Build.Event)
+	assert.Equal(t, drone.StatusSuccess, actual.Build.Status)
+
+	if assert.Len(t, hook.AllEntries(), 1) {
+		assert.Equal(t, `unknown event "pull-request"`, hook.LastEntry().Message)
+		assert.Equal(t, "build.event", hook.LastEntry().Data["flag"])
+	}
+}
+
+// Test the host CI fills timestamps not provided by Drone
+func TestPipelineHostTimes(t *testing.T) {
+	dronetest.ClearEnv(t)
+
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("CI_PIPELINE_CREATED_AT", "2026-01-02T03:04:05Z")
+
+	var actual drone.Pipeline
+
+	app := &cli.App{
+		Flags: Flags(),
+		Action: func(ctx *cli.Context) error {
+			actual = PipelineFromContext(ctx)
+			return nil
+		},
+	}
+
+	assert.NoError(t, app.Run([]string{"plugin"}))
+	assert.Equal(t, drone.HostGitLab, actual.System.Kind)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), actual.Build.Created)
+	assert.True(t, actual.Build.Started.IsZero())
+	assert.True(t, actual.Stage.Finished.IsZero())
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026, the Drone Plugins project authors.
+// Please see the AUTHORS file for details. All rights reserved.
+// Use of this source code is governed by an Apache 2.0 license that can be
+// found in the LICENSE file.
+
+package urfave
+
+import (
+	"github.com/drone-plugins/drone-plugin-lib/drone"
+	"github.com/urfave/cli/v2"
+)
+
+// workspaceFlags has the cli.Flags for the drone.Workspace.
+func workspaceFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "workspace.dir",
+			Usage: "workspace directory",
+			EnvVars: []string{
+				"DRONE_WORKSPACE",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "workspace.base",
+			Usage: "workspace base",
+			EnvVars: []string{
+				"DRONE_WORKSPACE_BASE",
+			},
+		},
+		&cli.StringFlag{
+			Name:  "workspace.path",
+			Usage: "workspace path",
+			EnvVars: []string{
+				"DRONE_WORKSPACE_PATH",
+			},
+		},
+	}
+}
+
+// workspaceFromContext creates a drone.Workspace from the cli.Context.
+func workspaceFromContext(ctx *cli.Context) drone.Workspace {
+	return drone.Workspace{
+		Dir:  ctx.String("workspace.dir"),
+		Base: ctx.String("workspace.base"),
+		Path: ctx.String("workspace.path"),
+	}
+}